@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/art"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// artGap is the space between drawings and the text or drawing next to
+// them.
+const artGap = "  "
+
+// drawsArt reports whether icon art and charts are drawn to w, and whether
+// in ASCII: with --ascii, or when the locale is not UTF-8. Screen readers
+// get neither.
+func drawsArt(w io.Writer) (ascii, ok bool) {
+	switch out.ModeOf(w) {
+	case out.TextASCII:
+		return true, true
+	case out.TextScreenReader:
+		return false, false
+	}
+	return !out.UnicodeLocale(), true
+}
+
+// besideArt puts the panel for icon to the left of lines, indented by two
+// spaces. The lines are returned as they are when w gets no art or when
+// they would not fit next to it.
+func besideArt(w io.Writer, icon int, lines []string) []string {
+	ascii, ok := drawsArt(w)
+	if !ok || 2+art.PanelWidth+len(artGap)+linesWidth(w, lines) > out.Width(w) {
+		return lines
+	}
+	panel := art.Panel(icon, ascii)
+	drawn := make([]string, max(len(panel), len(lines)))
+	for i := range drawn {
+		left := strings.Repeat(" ", art.PanelWidth)
+		if i < len(panel) {
+			left = panel[i]
+		}
+		var right string
+		if i < len(lines) {
+			right = strings.TrimPrefix(lines[i], "  ")
+		}
+		drawn[i] = strings.TrimRight("  "+left+artGap+right, " ")
+	}
+	return drawn
+}
+
+// artStrip draws the compact art of each forecast day side by side, with
+// the day above and the temperatures below, centred in columns that wrap to
+// the width of w. It returns no lines when w gets no art.
+func artStrip(w io.Writer, p out.Palette, u units.System, forecast []api.DayForecast, now time.Time) []string {
+	ascii, ok := drawsArt(w)
+	if !ok {
+		return nil
+	}
+	type column struct {
+		cells []string
+		width int
+	}
+	columns := make([]column, len(forecast))
+	for i, day := range forecast {
+		label := out.Transliterate(w, forecastDayLabel(day, now))
+		temps := out.Transliterate(w, fmt.Sprintf("%.0f/%.0f%s", u.Temp(day.TemperatureMin), u.Temp(day.TemperatureMax), u.TempUnit()))
+		c := column{width: max(art.CompactWidth, out.StringWidth(label), out.StringWidth(temps))}
+		c.cells = append(c.cells, label)
+		c.cells = append(c.cells, art.Compact(day.IconDay, ascii)...)
+		c.cells = append(c.cells, p.Temp(temps, day.TemperatureMax))
+		columns[i] = c
+	}
+
+	var lines []string
+	width := out.Width(w)
+	for start := 0; start < len(columns); {
+		// As many columns as fit, but at least one.
+		end, used := start+1, 2+columns[start].width
+		for end < len(columns) && used+len(artGap)+columns[end].width <= width {
+			used += len(artGap) + columns[end].width
+			end++
+		}
+		if start > 0 {
+			lines = append(lines, "")
+		}
+		for row := range columns[start].cells {
+			var b strings.Builder
+			b.WriteString("  ")
+			for i, c := range columns[start:end] {
+				if i > 0 {
+					b.WriteString(artGap)
+				}
+				// Centre the cell in its column.
+				cell := c.cells[row]
+				b.WriteString(out.PadRight(strings.Repeat(" ", (c.width-out.StringWidth(cell))/2)+cell, c.width))
+			}
+			lines = append(lines, strings.TrimRight(b.String(), " "))
+		}
+		start = end
+	}
+	return lines
+}
+
+// linesWidth returns the width of the widest line as written to w.
+func linesWidth(w io.Writer, lines []string) int {
+	widest := 0
+	for _, l := range lines {
+		widest = max(widest, out.StringWidth(out.Transliterate(w, l)))
+	}
+	return widest
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/art"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// utf8Locale makes the test independent of the locale it runs in.
+func utf8Locale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "C.UTF-8")
+}
+
+// --- drawsArt ---
+
+func TestDrawsArt(t *testing.T) {
+	utf8Locale(t)
+	var buf bytes.Buffer
+	for _, tc := range []struct {
+		w         io.Writer
+		ascii, ok bool
+	}{
+		{&buf, false, true},
+		{out.NewTextWriter(&buf, out.TextASCII), true, true},
+		{out.NewTextWriter(&buf, out.TextScreenReader), false, false},
+	} {
+		if ascii, ok := drawsArt(tc.w); ascii != tc.ascii || ok != tc.ok {
+			t.Errorf("drawsArt(%T) = %v, %v; want %v, %v", tc.w, ascii, ok, tc.ascii, tc.ok)
+		}
+	}
+	t.Setenv("LANG", "C")
+	if ascii, ok := drawsArt(&buf); !ascii || !ok {
+		t.Errorf("LANG=C: drawsArt() = %v, %v; want ASCII", ascii, ok)
+	}
+}
+
+// --- besideArt ---
+
+func TestBesideArt(t *testing.T) {
+	utf8Locale(t)
+	t.Setenv("COLUMNS", "80")
+	lines := []string{"  Sunny", "  Temperature : 20.0 °C"}
+	got := besideArt(&bytes.Buffer{}, 1, lines)
+	panel := art.Panel(1, false)
+	if len(got) != art.PanelHeight {
+		t.Fatalf("besideArt() = %d lines, want %d:\n%s", len(got), art.PanelHeight, strings.Join(got, "\n"))
+	}
+	if want := "  " + panel[1] + artGap + "Temperature : 20.0 °C"; got[1] != want {
+		t.Errorf("line 1 = %q, want %q", got[1], want)
+	}
+	if strings.HasSuffix(got[4], " ") {
+		t.Errorf("line 4 = %q, want no trailing spaces", got[4])
+	}
+}
+
+func TestBesideArt_fallsBack(t *testing.T) {
+	lines := []string{"  Sunny", "  Temperature : 20.0 °C"}
+
+	t.Setenv("COLUMNS", "30")
+	if got := besideArt(&bytes.Buffer{}, 1, lines); strings.Join(got, "\n") != strings.Join(lines, "\n") {
+		t.Errorf("narrow terminal: %q, want the lines alone", got)
+	}
+
+	t.Setenv("COLUMNS", "80")
+	sr := out.NewTextWriter(&bytes.Buffer{}, out.TextScreenReader)
+	if got := besideArt(sr, 1, lines); len(got) != len(lines) {
+		t.Errorf("screen reader: %q, want the lines alone", got)
+	}
+}
+
+// --- artStrip ---
+
+func TestArtStrip(t *testing.T) {
+	utf8Locale(t)
+	days := []api.DayForecast{
+		{DayDate: "2026-02-20", IconDay: 1, TemperatureMin: -3, TemperatureMax: 4},
+		{DayDate: "2026-02-21", IconDay: 35, TemperatureMin: 2, TemperatureMax: 9},
+		{DayDate: "2026-02-22", IconDay: 12, TemperatureMin: -5, TemperatureMax: 0},
+	}
+	t.Setenv("COLUMNS", "80")
+	got := artStrip(&bytes.Buffer{}, out.Palette{}, units.Metric, days, anchor)
+	if len(got) != art.CompactHeight+2 {
+		t.Fatalf("artStrip() = %d lines, want %d:\n%s", len(got), art.CompactHeight+2, strings.Join(got, "\n"))
+	}
+	for _, want := range []string{"Today", "Tomorrow", "Sun 22 Feb"} {
+		if !strings.Contains(got[0], want) {
+			t.Errorf("header %q lacks %q", got[0], want)
+		}
+	}
+	if !strings.Contains(got[len(got)-1], "-3/4°C") {
+		t.Errorf("last line %q lacks the temperatures", got[len(got)-1])
+	}
+
+	// Too narrow for more than one day per row.
+	t.Setenv("COLUMNS", "12")
+	got = artStrip(&bytes.Buffer{}, out.Palette{}, units.Metric, days, anchor)
+	if rows := len(days)*(art.CompactHeight+2) + len(days) - 1; len(got) != rows {
+		t.Errorf("narrow: %d lines, want %d:\n%s", len(got), rows, strings.Join(got, "\n"))
+	}
+}
+
+func TestArtStrip_textModes(t *testing.T) {
+	days := []api.DayForecast{{DayDate: "2026-02-20", IconDay: 10, TemperatureMin: -3, TemperatureMax: 4}}
+	var buf bytes.Buffer
+	ascii := out.NewTextWriter(&buf, out.TextASCII)
+	for _, l := range artStrip(ascii, out.Palette{}, units.Metric, days, anchor) {
+		if l != out.ASCII(l) {
+			t.Errorf("--ascii line %q is not ASCII", l)
+		}
+	}
+	if got := artStrip(out.NewTextWriter(&buf, out.TextScreenReader), out.Palette{}, units.Metric, days, anchor); got != nil {
+		t.Errorf("screen reader: %q, want no art", got)
+	}
+}
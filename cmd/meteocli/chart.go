@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/chart"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// The rain chart shows the hour before the start of the window and at least
+// two hours after it.
+const (
+	rainChartBefore = time.Hour
+	rainChartAfter  = 2 * time.Hour
+)
+
+// rainChartTop is the lowest intensity, in mm/h, that fills a sparkline
+// cell, so that drizzle does not look like a downpour.
+const rainChartTop = 1.0
+
+// chartSteps are the column widths of the rain chart, finest first. The
+// finest that fits the terminal is used.
+var chartSteps = []time.Duration{
+	10 * time.Minute, 20 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour,
+}
+
+// chartLabelEvery are the intervals between time labels, the shortest
+// that leaves room for "15:04" being used.
+var chartLabelEvery = []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour}
+
+// rainChart draws the precipitation intensity of the graph data as a
+// sparkline with a time axis, from an hour before start to the end of the
+// window, and marks start with mark. Times are in start's location. It
+// returns no lines without graph data or when w gets no charts.
+func rainChart(w io.Writer, p out.Palette, u units.System, g *api.GraphData, start time.Time, window time.Duration, threshold float64, mark string) []string {
+	ascii, ok := drawsArt(w)
+	series := g.PrecipitationSeries().In(start.Location())
+	if !ok || len(series) == 0 {
+		return nil
+	}
+	from := start.Add(-rainChartBefore)
+	if first := series[0].Start; from.Before(first) {
+		from = first
+	}
+	to := start.Add(max(window, rainChartAfter))
+	if last := series[len(series)-1].End; to.After(last) {
+		to = last
+	}
+	if !to.After(from) {
+		return nil
+	}
+
+	room := out.Width(w) - 4
+	step := chartSteps[len(chartSteps)-1]
+	for _, s := range chartSteps {
+		if int((to.Sub(from)+s-1)/s) <= room {
+			step = s
+			break
+		}
+	}
+	every := chartLabelEvery[len(chartLabelEvery)-1]
+	for _, e := range chartLabelEvery {
+		if e/step >= 6 {
+			every = e
+			break
+		}
+	}
+
+	// Columns start on whole steps so that the labels fall on the hour.
+	from = from.Truncate(step)
+	values := make([]float64, int((to.Sub(from)+step-1)/step))
+	var ticks, labels []chart.Mark
+	peak := 0.0
+	for i := range values {
+		t := from.Add(time.Duration(i) * step)
+		var covered time.Duration
+		slots := series.Window(t, t.Add(step))
+		for _, s := range slots {
+			covered += s.Duration()
+		}
+		if covered > 0 {
+			values[i] = slots.Total() * float64(time.Hour) / float64(covered)
+			peak = max(peak, values[i])
+		}
+		if t.Minute() == 0 && time.Duration(t.Hour())*time.Hour%every == 0 {
+			ticks = append(ticks, chart.Mark{Col: i, Label: tickGlyph(ascii)})
+			labels = append(labels, chart.Mark{Col: i, Label: t.Format("15:04")})
+		}
+	}
+	top := max(peak, rainChartTop)
+	now := chart.Mark{Col: int(start.Sub(from) / step), Label: markerGlyph(ascii) + " " + mark}
+
+	return []string{
+		fmt.Sprintf("  Rain intensity (%s = %s)", chart.Full(ascii), precipRate(u, top, 0)),
+		"  " + p.Rain(chart.Sparkline(values, top, ascii), peak >= threshold),
+		strings.TrimRight("  "+chart.Axis(ticks), " "),
+		"  " + chart.Axis(labels),
+		"  " + chart.Axis([]chart.Mark{now}),
+	}
+}
+
+// temperatureChart draws the low to high temperature of each forecast day as
+// a band on a scale shared by all days, with today marked.
+func temperatureChart(w io.Writer, p out.Palette, u units.System, forecast []api.DayForecast, now time.Time) []string {
+	ascii, ok := drawsArt(w)
+	if !ok || len(forecast) == 0 {
+		return nil
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	labels := make([]string, len(forecast))
+	values := make([]string, len(forecast))
+	labelWidth, valueWidth := 0, 0
+	for i, day := range forecast {
+		lo = min(lo, u.Temp(day.TemperatureMin))
+		hi = max(hi, u.Temp(day.TemperatureMax))
+		labels[i] = out.Transliterate(w, forecastDayLabel(day, now))
+		values[i] = out.Transliterate(w, fmt.Sprintf("%.0f/%.0f%s", u.Temp(day.TemperatureMin), u.Temp(day.TemperatureMax), u.TempUnit()))
+		labelWidth = max(labelWidth, out.StringWidth(labels[i]))
+		valueWidth = max(valueWidth, out.StringWidth(values[i]))
+	}
+	lo, hi = math.Floor(lo), math.Ceil(hi)
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	// "  ▸ " + label + " " + band + "  " + values
+	width := min(max(out.Width(w)-4-labelWidth-1-2-valueWidth, 10), 40)
+	today := api.DateOf(now)
+	loLabel := out.Transliterate(w, fmt.Sprintf("%.0f%s", lo, u.TempUnit()))
+	hiLabel := out.Transliterate(w, fmt.Sprintf("%.0f%s", hi, u.TempUnit()))
+	lines := []string{
+		"  Temperature range",
+		"  " + strings.Repeat(" ", 2+labelWidth+1) +
+			chart.Axis([]chart.Mark{{Col: 0, Label: loLabel}, {Col: width - out.StringWidth(hiLabel), Label: hiLabel}}),
+	}
+	for i, day := range forecast {
+		marker := "  "
+		if d, err := day.Date(); err == nil && d == today {
+			marker = "▸ "
+			if ascii {
+				marker = "> "
+			}
+		}
+		band := chart.Band(u.Temp(day.TemperatureMin), u.Temp(day.TemperatureMax), lo, hi, width, ascii)
+		lines = append(lines, fmt.Sprintf("  %s%s %s  %s",
+			marker, out.PadRight(labels[i], labelWidth), p.Temp(band, day.TemperatureMax), values[i]))
+	}
+	return lines
+}
+
+// tickGlyph marks a labelled column below a chart.
+func tickGlyph(ascii bool) string {
+	if ascii {
+		return "'"
+	}
+	return "╵"
+}
+
+// markerGlyph points at the current column of a chart.
+func markerGlyph(ascii bool) string {
+	if ascii {
+		return "^"
+	}
+	return "▲"
+}
+
+// printChart writes the lines of a chart followed by a separator as wide as
+// the chart. It writes nothing when there are no lines.
+func printChart(w io.Writer, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	for _, l := range lines {
+		fmt.Fprintln(w, l)
+	}
+	out.Sep(w, min(linesWidth(w, lines), out.Width(w)))
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// --- rainChart ---
+
+func TestRainChart(t *testing.T) {
+	utf8Locale(t)
+	t.Setenv("COLUMNS", "80")
+	// Three dry hours, with a shower from 13:20 to 13:50.
+	hi := make([]float64, 18)
+	hi[8], hi[9], hi[10] = 0.1, 0.5, 0.2
+	g := makeGraph(hi, nil)
+	start := anchor.Add(time.Hour)
+
+	got := rainChart(&bytes.Buffer{}, out.Palette{}, units.Metric, g, start, 30*time.Minute, defaultRainThreshold, "now")
+	if len(got) != 5 {
+		t.Fatalf("rainChart() = %d lines, want 5", len(got))
+	}
+	if want := "  Rain intensity (█ = 3.0 mm/h)"; got[0] != want {
+		t.Errorf("header = %q, want %q", got[0], want)
+	}
+	// One column per 10 minutes from 12:00 (the start of the data) to 15:00.
+	if want := "  " + strings.Repeat(" ", 8) + "▂█▄" + strings.Repeat(" ", 7); got[1] != want {
+		t.Errorf("sparkline = %q, want %q", got[1], want)
+	}
+	for _, want := range []string{"12:00", "13:00", "14:00"} {
+		if !strings.Contains(got[3], want) {
+			t.Errorf("labels %q lack %q", got[3], want)
+		}
+	}
+	if want := "  " + strings.Repeat(" ", 6) + "▲ now"; got[4] != want {
+		t.Errorf("marker = %q, want %q", got[4], want)
+	}
+}
+
+func TestRainChart_noData(t *testing.T) {
+	utf8Locale(t)
+	if got := rainChart(&bytes.Buffer{}, out.Palette{}, units.Metric, nil, anchor, time.Hour, defaultRainThreshold, "now"); got != nil {
+		t.Errorf("no graph: %q, want no chart", got)
+	}
+	g := makeGraph([]float64{0.1, 0.2}, nil)
+	if got := rainChart(&bytes.Buffer{}, out.Palette{}, units.Metric, g, anchor.Add(3*time.Hour), time.Hour, defaultRainThreshold, "now"); got != nil {
+		t.Errorf("after the data: %q, want no chart", got)
+	}
+}
+
+func TestRainChart_textModes(t *testing.T) {
+	g := makeGraph([]float64{0, 0.3, 1.2, 0.4, 0, 0}, []float64{0.5, 2})
+	var buf bytes.Buffer
+	got := rainChart(out.NewTextWriter(&buf, out.TextASCII), out.Palette{}, units.Metric, g, anchor, time.Hour, defaultRainThreshold, "17:30")
+	if len(got) == 0 {
+		t.Fatal("--ascii: no chart")
+	}
+	for _, l := range got {
+		if l != out.ASCII(l) {
+			t.Errorf("--ascii line %q is not ASCII", l)
+		}
+	}
+	if !strings.HasSuffix(got[len(got)-1], "^ 17:30") {
+		t.Errorf("--ascii marker = %q, want it to end in %q", got[len(got)-1], "^ 17:30")
+	}
+	if got := rainChart(out.NewTextWriter(&buf, out.TextScreenReader), out.Palette{}, units.Metric, g, anchor, time.Hour, defaultRainThreshold, "now"); got != nil {
+		t.Errorf("screen reader: %q, want no chart", got)
+	}
+}
+
+// --- temperatureChart ---
+
+func TestTemperatureChart(t *testing.T) {
+	utf8Locale(t)
+	t.Setenv("COLUMNS", "80")
+	days := []api.DayForecast{
+		{DayDate: "2026-02-20", TemperatureMin: -3, TemperatureMax: 4},
+		{DayDate: "2026-02-21", TemperatureMin: 2, TemperatureMax: 9},
+	}
+	got := temperatureChart(&bytes.Buffer{}, out.Palette{}, units.Metric, days, anchor)
+	if len(got) != 2+len(days) {
+		t.Fatalf("temperatureChart() = %d lines, want %d", len(got), 2+len(days))
+	}
+	if !strings.HasPrefix(got[2], "  ▸ Today ") || !strings.HasPrefix(got[3], "    Tomorrow ") {
+		t.Errorf("rows %q, %q: want today marked", got[2], got[3])
+	}
+	// The scale runs from -3 to 9 over the widest band of 40 cells.
+	if !strings.Contains(got[1], "-3°C") || !strings.HasSuffix(got[1], "9°C") {
+		t.Errorf("axis = %q", got[1])
+	}
+	today := strings.Count(got[2], "█")
+	tomorrow := strings.Count(got[3], "█")
+	if today != 24 || tomorrow != 24 {
+		t.Errorf("band widths = %d, %d; want 24, 24", today, tomorrow)
+	}
+	if !strings.HasSuffix(got[2], "-3/4°C") {
+		t.Errorf("row %q lacks the temperatures", got[2])
+	}
+}
+
+func TestTemperatureChart_textModes(t *testing.T) {
+	days := []api.DayForecast{{DayDate: "2026-02-20", TemperatureMin: -3, TemperatureMax: 4}}
+	var buf bytes.Buffer
+	for _, l := range temperatureChart(out.NewTextWriter(&buf, out.TextASCII), out.Palette{}, units.Metric, days, anchor) {
+		if l != out.ASCII(l) {
+			t.Errorf("--ascii line %q is not ASCII", l)
+		}
+	}
+	if got := temperatureChart(out.NewTextWriter(&buf, out.TextScreenReader), out.Palette{}, units.Metric, days, anchor); got != nil {
+		t.Errorf("screen reader: %q, want no chart", got)
+	}
+}
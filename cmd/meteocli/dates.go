@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+)
+
+// dayLabel names d relative to today: "Today", "Tomorrow", "Yesterday", or
+// the short weekday and date otherwise, e.g. "Tue 20 Oct".
+func dayLabel(d, today api.Date) string {
+	switch d.DaysSince(today) {
+	case 0:
+		return "Today"
+	case 1:
+		return "Tomorrow"
+	case -1:
+		return "Yesterday"
+	}
+	return d.In(time.UTC).Format("Mon 2 Jan")
+}
+
+// forecastDayLabel labels the day of a daily forecast relative to now, and
+// falls back to the date as the API sent it when it cannot be parsed.
+func forecastDayLabel(d api.DayForecast, now time.Time) string {
+	date, err := d.Date()
+	if err != nil {
+		return d.DayDate
+	}
+	return dayLabel(date, api.DateOf(now))
+}
+
+// timeLabel formats t in now's location with its day relative to now and
+// the zone, e.g. "Today 14:20 CEST".
+func timeLabel(t, now time.Time) string {
+	t = t.In(now.Location())
+	return dayLabel(api.DateOf(t), api.DateOf(now)) + " " + t.Format("15:04 MST")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+)
+
+// --- dayLabel ---
+
+func TestDayLabel(t *testing.T) {
+	today := api.Date{Year: 2026, Month: time.October, Day: 18}
+	cases := []struct {
+		days int
+		want string
+	}{
+		{0, "Today"},
+		{1, "Tomorrow"},
+		{-1, "Yesterday"},
+		{2, "Tue 20 Oct"},
+		{14, "Sun 1 Nov"},
+	}
+	for _, tc := range cases {
+		if got := dayLabel(today.AddDays(tc.days), today); got != tc.want {
+			t.Errorf("dayLabel(today%+d) = %q, want %q", tc.days, got, tc.want)
+		}
+	}
+}
+
+func TestForecastDayLabel_usesNowsZone(t *testing.T) {
+	// 23:30 UTC on the 17th is already the 18th in Zurich.
+	now := time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC)
+	day := api.DayForecast{DayDate: "2026-10-18"}
+	if got := forecastDayLabel(day, now.In(zurich)); got != "Today" {
+		t.Errorf("in Zurich: %q, want Today", got)
+	}
+	if got := forecastDayLabel(day, now); got != "Tomorrow" {
+		t.Errorf("in UTC: %q, want Tomorrow", got)
+	}
+	if got := forecastDayLabel(api.DayForecast{DayDate: "soon"}, now); got != "soon" {
+		t.Errorf("unparsable date: %q, want it unchanged", got)
+	}
+}
+
+// --- timeLabel ---
+
+func TestTimeLabel(t *testing.T) {
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 2, 20, 11, 50, 0, 0, time.UTC)
+	if got := timeLabel(at, now.In(zurich)); got != "Today 12:50 CET" {
+		t.Errorf("in Zurich: %q, want \"Today 12:50 CET\"", got)
+	}
+	if got := timeLabel(at.Add(-12*time.Hour), now); got != "Yesterday 23:50 UTC" {
+		t.Errorf("in UTC: %q, want \"Yesterday 23:50 UTC\"", got)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package main
+
+import "syscall"
+
+// detachedProcAttr returns nil; the platform has no way to detach a child.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
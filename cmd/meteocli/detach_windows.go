@@ -0,0 +1,17 @@
+//go:build windows
+
+package main
+
+import "syscall"
+
+// Process creation flags not exported by package syscall.
+const (
+	detachedProcess       = 0x00000008
+	createNewProcessGroup = 0x00000200
+)
+
+// detachedProcAttr starts the child without a console and outside the
+// shell's process group.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: detachedProcess | createNewProcessGroup}
+}
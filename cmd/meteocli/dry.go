@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// dryWindow is a contiguous stretch of slots that stay under the threshold.
+type dryWindow struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Minutes int       `json:"minutes"`
+	MaxMM   float64   `json:"max_mm"`
+}
+
+// dryResult is the structured result for the dry command.
+type dryResult struct {
+	PLZ             int           `json:"plz"`
+	At              *time.Time    `json:"at,omitempty"`
+	DurationMinutes int           `json:"duration_minutes"`
+	WithinMinutes   int           `json:"within_minutes"`
+	MaxMM           float64       `json:"max_mm"`
+	Windows         []dryWindow   `json:"windows"`
+	Message         string        `json:"message"`
+	Explanation     []explainStep `json:"explanation,omitempty"`
+}
+
+func newDryCmd(flags *rootFlags) *cobra.Command {
+	var plz int
+	var duration time.Duration
+	var within time.Duration
+	var maxMM float64
+	var explain bool
+	var at string
+
+	cmd := &cobra.Command{
+		Use:   "dry",
+		Short: "Find dry time windows in the precipitation forecast for a Swiss postal code",
+		Example: `  # Windows of at least 45 minutes without rain in the next 12 hours
+  meteocli dry --zip 8000 --duration 45m --within 12h
+
+  # Dry windows tomorrow morning
+  meteocli dry --zip 8000 --at "tomorrow 06:00" --within 6h
+
+  # Tolerate drizzle up to 0.2 mm per 10 minutes
+  meteocli dry --zip 3000 --duration 1h --max-mm 0.2
+
+  # Show how each forecast slot was judged
+  meteocli dry --zip 8000 --explain`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requirePLZ(plz); err != nil {
+				return err
+			}
+			if duration < hiInterval {
+				return fmt.Errorf("--duration must be at least 10m")
+			}
+			if within < duration || within > 24*time.Hour {
+				return fmt.Errorf("--within must be between --duration and 24h")
+			}
+			if maxMM < 0 {
+				return fmt.Errorf("--max-mm must not be negative")
+			}
+
+			now, err := parseAt(at, flags.now(), flags.loc)
+			if err != nil {
+				return fmt.Errorf("--at: %w", err)
+			}
+
+			detail, err := flags.fetchDetail(plz)
+			if err != nil {
+				return err
+			}
+
+			result := findDryWindows(plz, detail, now, duration, within, maxMM)
+			result.At = shiftedAt(at, now, flags.loc)
+			if !explain {
+				result.Explanation = nil
+			}
+
+			return flags.render(cmd.OutOrStdout(), result, func(w io.Writer) {
+				printDryWindows(w, result)
+				if len(result.Explanation) > 0 {
+					printExplanation(w, result.Explanation)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&plz, "zip", 0, "Swiss postal code (e.g. 8000 for Zurich)")
+	cmd.Flags().DurationVar(&duration, "duration", 30*time.Minute, "minimum length of a dry window (e.g. 45m, 2h)")
+	cmd.Flags().DurationVar(&within, "within", 12*time.Hour, "look-ahead horizon (up to 24h)")
+	cmd.Flags().StringVar(&at, "at", "", `start of the search in the --tz zone, e.g. "tomorrow 06:00" or "+3h" (default now)`)
+	cmd.Flags().Float64Var(&maxMM, "max-mm", 0.1, "highest precipitation per 10 minutes that still counts as dry (mm)")
+	cmd.Flags().BoolVar(&explain, "explain", false, "show the forecast slots and rules that led to the result")
+	_ = cmd.MarkFlagRequired("zip")
+	return cmd
+}
+
+// findDryWindows scans the graph slots in [now, now+within] and returns every
+// contiguous run of slots with at most maxMM precipitation per 10 minutes that
+// lasts at least duration. Windows are ranked longest first, then by start
+// time.
+func findDryWindows(plz int, detail *api.PLZDetail, now time.Time, duration, within time.Duration, maxMM float64) dryResult {
+	result := dryResult{
+		PLZ:             plz,
+		DurationMinutes: int(duration / time.Minute),
+		WithinMinutes:   int(within / time.Minute),
+		MaxMM:           maxMM,
+		Windows:         []dryWindow{},
+	}
+
+	horizon := now.Add(within)
+	result.Explanation = []explainStep{{
+		Step: stepWindow,
+		Detail: fmt.Sprintf("Window %s–%s (%s), dry at ≤ %.2f mm per 10 min, for at least %s",
+			now.Format("15:04"), horizon.Format("15:04"), formatMinutes(result.WithinMinutes), maxMM, formatMinutes(result.DurationMinutes)),
+	}}
+	series := detail.Graph.PrecipitationSeries().In(now.Location())
+	slots := series.Window(now, horizon)
+	if len(slots) == 0 {
+		result.Message = "Hourly precipitation data unavailable"
+		result.Explanation = append(result.Explanation, explainStep{Step: stepVerdict, Detail: "No graph data overlaps the window; the daily forecast cannot place dry windows"})
+		return result
+	}
+	result.Explanation = append(result.Explanation, explainSource(detail.Graph, series, now.Location())...)
+	for i, rs := range rainTimeline(slots) {
+		rs := rs
+		mm := slots[i].Amount * float64(hiInterval) / float64(slots[i].Duration())
+		verdict := "dry"
+		if mm > maxMM {
+			verdict = "wet"
+		}
+		result.Explanation = append(result.Explanation, explainStep{
+			Step: stepSlot,
+			Detail: fmt.Sprintf("%s–%s  %-3s  %.2f mm = %.2f mm per 10 min → %s",
+				rs.Start.Format("15:04"), rs.End.Format("15:04"), rs.Resolution, rs.MM, mm, verdict),
+			Slot: &rs,
+		})
+	}
+
+	var cur *dryWindow
+	flush := func() {
+		if cur != nil && cur.End.Sub(cur.Start) >= duration {
+			cur.Minutes = int(cur.End.Sub(cur.Start) / time.Minute)
+			result.Windows = append(result.Windows, *cur)
+		}
+		cur = nil
+	}
+	for _, s := range slots {
+		// Compare hourly and 10-minute slots on the same per-10-minute scale.
+		mm := s.Amount * float64(hiInterval) / float64(s.Duration())
+		if mm > maxMM || (cur != nil && s.Start.After(cur.End)) {
+			flush()
+		}
+		if mm > maxMM {
+			continue
+		}
+		if cur == nil {
+			cur = &dryWindow{Start: s.Start}
+		}
+		cur.End = s.End
+		if mm > cur.MaxMM {
+			cur.MaxMM = mm
+		}
+	}
+	flush()
+
+	sort.SliceStable(result.Windows, func(i, j int) bool {
+		a, b := result.Windows[i], result.Windows[j]
+		if a.Minutes != b.Minutes {
+			return a.Minutes > b.Minutes
+		}
+		return a.Start.Before(b.Start)
+	})
+
+	switch n := len(result.Windows); n {
+	case 0:
+		result.Message = fmt.Sprintf("No dry window of %s in the next %s", formatMinutes(result.DurationMinutes), formatMinutes(result.WithinMinutes))
+	case 1:
+		result.Message = "1 dry window found"
+	default:
+		result.Message = fmt.Sprintf("%d dry windows found", n)
+	}
+	result.Explanation = append(result.Explanation, explainStep{Step: stepVerdict, Detail: result.Message})
+	return result
+}
+
+// formatMinutes renders a minute count as e.g. "45m", "2h" or "3h40m".
+func formatMinutes(m int) string {
+	switch {
+	case m < 60:
+		return fmt.Sprintf("%dm", m)
+	case m%60 == 0:
+		return fmt.Sprintf("%dh", m/60)
+	default:
+		return fmt.Sprintf("%dh%02dm", m/60, m%60)
+	}
+}
+
+func printDryWindows(w io.Writer, r dryResult) {
+	out.Sep(w, 50)
+	if r.At != nil {
+		fmt.Fprintf(w, "  Dry windows for PLZ %d  (≥%s within %s from %s)\n",
+			r.PLZ, formatMinutes(r.DurationMinutes), formatMinutes(r.WithinMinutes), r.At.Format("Mon 15:04"))
+	} else {
+		fmt.Fprintf(w, "  Dry windows for PLZ %d  (≥%s within %s)\n",
+			r.PLZ, formatMinutes(r.DurationMinutes), formatMinutes(r.WithinMinutes))
+	}
+	out.Sep(w, 50)
+	if len(r.Windows) == 0 {
+		fmt.Fprintf(w, "  %s\n", r.Message)
+		out.Sep(w, 50)
+		return
+	}
+	for i, win := range r.Windows {
+		fmt.Fprintf(w, "  %2d. %s – %s  %8s\n", i+1, win.Start.Format("Mon 15:04"), win.End.Format("15:04"), formatMinutes(win.Minutes))
+	}
+	out.Sep(w, 50)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+)
+
+// --- findDryWindows ---
+
+func TestFindDryWindows_rankedByLength(t *testing.T) {
+	// Dry 12:00–12:30, wet 12:30–12:40, dry 12:40–13:40.
+	slots := []float64{0, 0, 0, 1.2, 0, 0, 0, 0, 0, 0}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := findDryWindows(8000, detail, anchor, 30*time.Minute, 2*time.Hour, 0.1)
+
+	if len(result.Windows) != 2 {
+		t.Fatalf("len(Windows) = %d, want 2", len(result.Windows))
+	}
+	first, second := result.Windows[0], result.Windows[1]
+	if first.Minutes != 60 || !first.Start.Equal(anchor.Add(40*time.Minute)) {
+		t.Errorf("first window = %v (%d min), want 12:40 (60 min)", first.Start, first.Minutes)
+	}
+	if second.Minutes != 30 || !second.Start.Equal(anchor) {
+		t.Errorf("second window = %v (%d min), want 12:00 (30 min)", second.Start, second.Minutes)
+	}
+}
+
+func TestFindDryWindows_tooShortDropped(t *testing.T) {
+	slots := []float64{0, 0, 0.5, 0, 0, 0, 0, 0.5}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := findDryWindows(8000, detail, anchor, 45*time.Minute, 2*time.Hour, 0.1)
+	if len(result.Windows) != 0 {
+		t.Errorf("len(Windows) = %d, want 0 (no window reaches 45 min)", len(result.Windows))
+	}
+	if result.Message == "" {
+		t.Error("Message should explain that no window was found")
+	}
+}
+
+func TestFindDryWindows_thresholdAllowsDrizzle(t *testing.T) {
+	slots := []float64{0, 0.05, 0, 0.1, 0}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := findDryWindows(8000, detail, anchor, 30*time.Minute, time.Hour, 0.1)
+	if len(result.Windows) != 1 || result.Windows[0].Minutes != 50 {
+		t.Fatalf("Windows = %+v, want a single 50-minute window", result.Windows)
+	}
+	if result.Windows[0].MaxMM != 0.1 {
+		t.Errorf("MaxMM = %.2f, want 0.1", result.Windows[0].MaxMM)
+	}
+}
+
+func TestFindDryWindows_clippedToHorizon(t *testing.T) {
+	// Mid-slot start: the first window starts at now, not at the slot start.
+	slots := make([]float64, 12)
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	now := anchor.Add(5 * time.Minute)
+	result := findDryWindows(8000, detail, now, 30*time.Minute, time.Hour, 0.1)
+	if len(result.Windows) != 1 {
+		t.Fatalf("len(Windows) = %d, want 1", len(result.Windows))
+	}
+	w := result.Windows[0]
+	if !w.Start.Equal(now) || !w.End.Equal(now.Add(time.Hour)) {
+		t.Errorf("window = %v – %v, want %v – %v", w.Start, w.End, now, now.Add(time.Hour))
+	}
+}
+
+func TestFindDryWindows_noGraph(t *testing.T) {
+	result := findDryWindows(8000, &api.PLZDetail{}, anchor, 30*time.Minute, time.Hour, 0.1)
+	if result.Windows == nil || len(result.Windows) != 0 {
+		t.Errorf("Windows = %#v, want empty non-nil slice", result.Windows)
+	}
+}
+
+// --- formatMinutes ---
+
+func TestPrintDryWindows(t *testing.T) {
+	slots := []float64{0, 0, 0, 1.2, 0, 0, 0, 0, 0, 0}
+	result := findDryWindows(8000, &api.PLZDetail{Graph: makeGraph(slots, nil)}, anchor, 30*time.Minute, 2*time.Hour, 0.1)
+
+	var buf bytes.Buffer
+	printDryWindows(&buf, result)
+	got := buf.String()
+	for _, want := range []string{"Dry windows for PLZ 8000", "1. Fri 12:40 – 13:40", "2. Fri 12:00 – 12:30"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatMinutes(t *testing.T) {
+	cases := map[int]string{45: "45m", 60: "1h", 220: "3h40m", 725: "12h05m"}
+	for in, want := range cases {
+		if got := formatMinutes(in); got != want {
+			t.Errorf("formatMinutes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// --- CLI validation ---
+
+func TestExecute_dryDurationTooShort(t *testing.T) {
+	if err := execute([]string{"dry", "--zip", "8000", "--duration", "5m"}); err == nil {
+		t.Error("expected error for --duration 5m, got nil")
+	}
+}
+
+func TestExecute_dryWithinTooLong(t *testing.T) {
+	if err := execute([]string{"dry", "--zip", "8000", "--within", "25h"}); err == nil {
+		t.Error("expected error for --within 25h, got nil")
+	}
+}
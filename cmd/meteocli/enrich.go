@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// Values of --json.
+const (
+	jsonEnriched = "enriched" // API fields plus derived, human-friendly ones
+	jsonRaw      = "raw"      // API fields only
+)
+
+// jsonValue is the --json flag. A bare --json selects enriched output;
+// --json=raw keeps only the fields the API returns. "true" and "false" are
+// accepted for compatibility with the former boolean flag.
+type jsonValue struct {
+	asJSON *bool
+	raw    *bool
+}
+
+func (v jsonValue) String() string {
+	switch {
+	case v.asJSON == nil || !*v.asJSON:
+		return ""
+	case *v.raw:
+		return jsonRaw
+	}
+	return jsonEnriched
+}
+
+func (v jsonValue) Set(s string) error {
+	switch strings.ToLower(s) {
+	case jsonEnriched, "true":
+		*v.asJSON, *v.raw = true, false
+	case jsonRaw:
+		*v.asJSON, *v.raw = true, true
+	case "false":
+		*v.asJSON, *v.raw = false, false
+	default:
+		return fmt.Errorf("want %s or %s", jsonEnriched, jsonRaw)
+	}
+	return nil
+}
+
+func (v jsonValue) Type() string { return "mode" }
+
+// rawViewer is implemented by results whose structured form adds derived
+// fields to API data. rawView returns the result with only the API fields,
+// for --json=raw.
+type rawViewer interface {
+	rawView() any
+}
+
+// iconInfo describes a weather icon code.
+type iconInfo struct {
+	IconDescription string `json:"iconDescription,omitempty"`
+	IconEmoji       string `json:"iconEmoji,omitempty"`
+	IconCategory    string `json:"iconCategory,omitempty"`
+}
+
+func newIconInfo(code int) iconInfo {
+	return iconInfo{
+		IconDescription: api.IconDescription(code),
+		IconEmoji:       api.IconEmoji(code),
+		IconCategory:    api.IconCategory(code),
+	}
+}
+
+// dayView is a daily forecast with its weekday, ISO date and icon details.
+type dayView struct {
+	api.DayForecast
+	Weekday string `json:"weekday,omitempty"`
+	Date    string `json:"date,omitempty"`
+	iconInfo
+	Units *unitsInfo `json:"units,omitempty"`
+
+	// plz is the postal code of the forecast, for the csv column.
+	plz int
+}
+
+func newDayView(plz int, d api.DayForecast) dayView {
+	v := dayView{DayForecast: d, iconInfo: newIconInfo(d.IconDay), plz: plz}
+	if date, err := d.Date(); err == nil {
+		v.Weekday = date.Weekday().String()
+		v.Date = date.String()
+	}
+	return v
+}
+
+// dayViews is the result of the forecast command.
+type dayViews []dayView
+
+func newDayViews(plz int, days []api.DayForecast) dayViews {
+	views := make(dayViews, len(days))
+	for i, d := range days {
+		views[i] = newDayView(plz, d)
+	}
+	return views
+}
+
+func (v dayView) inUnits(u units.System) dayView {
+	v.TemperatureMin = u.Temp(v.TemperatureMin)
+	v.TemperatureMax = u.Temp(v.TemperatureMax)
+	v.Precipitation = u.Precip(v.Precipitation)
+	v.PrecipitationMin = u.Precip(v.PrecipitationMin)
+	v.PrecipitationMax = u.Precip(v.PrecipitationMax)
+	v.Units = newUnitsInfo(u)
+	return v
+}
+
+func (vs dayViews) inUnits(u units.System) any {
+	converted := make(dayViews, len(vs))
+	for i, v := range vs {
+		converted[i] = v.inUnits(u)
+	}
+	return converted
+}
+
+func (vs dayViews) rawView() any {
+	days := make([]api.DayForecast, len(vs))
+	for i, v := range vs {
+		days[i] = v.DayForecast
+	}
+	return days
+}
+
+// warningView is a warning with the names of its type and level and its
+// validity parsed into timestamps. The times are null when the API's value
+// is not RFC 3339.
+type warningView struct {
+	api.Warning
+	WarnTypeLabel  string     `json:"warnTypeLabel,omitempty"`
+	WarnLevelLabel string     `json:"warnLevelLabel,omitempty"`
+	ValidFromTime  *time.Time `json:"validFromTime,omitempty"`
+	ValidToTime    *time.Time `json:"validToTime,omitempty"`
+
+	// plz is the postal code the warning was issued for, for the csv
+	// column.
+	plz int
+}
+
+func newWarningView(plz int, w api.Warning, loc *time.Location) warningView {
+	v := warningView{
+		Warning:        w,
+		WarnTypeLabel:  warnTypeLabel(w.WarnType),
+		WarnLevelLabel: warnLevelLabel(w.WarnLevel),
+		plz:            plz,
+	}
+	if t, err := time.Parse(time.RFC3339, w.ValidFrom); err == nil {
+		t = t.In(loc)
+		v.ValidFromTime = &t
+	}
+	if t, err := time.Parse(time.RFC3339, w.ValidTo); err == nil {
+		t = t.In(loc)
+		v.ValidToTime = &t
+	}
+	return v
+}
+
+// warningViews is the result of the warnings command.
+type warningViews []warningView
+
+func newWarningViews(plz int, warnings []api.Warning, loc *time.Location) warningViews {
+	views := make(warningViews, len(warnings))
+	for i, w := range warnings {
+		views[i] = newWarningView(plz, w, loc)
+	}
+	return views
+}
+
+func (vs warningViews) rawView() any {
+	return vs.warnings()
+}
+
+// warnings returns the API warnings behind the views.
+func (vs warningViews) warnings() []api.Warning {
+	warnings := make([]api.Warning, len(vs))
+	for i, v := range vs {
+		warnings[i] = v.Warning
+	}
+	return warnings
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// --- newDayView ---
+
+func TestNewDayView(t *testing.T) {
+	v := newDayView(8000, api.DayForecast{DayDate: "20260220", IconDay: 35})
+	if v.Weekday != "Friday" || v.Date != "2026-02-20" {
+		t.Errorf("weekday, date = %q, %q; want Friday, 2026-02-20", v.Weekday, v.Date)
+	}
+	if v.IconDescription != api.IconDescription(35) || v.IconEmoji != api.IconEmoji(35) || v.IconCategory != api.IconCategory(35) {
+		t.Errorf("icon = %+v", v.iconInfo)
+	}
+}
+
+func TestNewDayView_unparsableDate(t *testing.T) {
+	v := newDayView(8000, api.DayForecast{DayDate: "today"})
+	if v.Weekday != "" || v.Date != "" {
+		t.Errorf("weekday, date = %q, %q; want both empty", v.Weekday, v.Date)
+	}
+}
+
+// --- newWarningView ---
+
+func TestNewWarningView(t *testing.T) {
+	v := newWarningView(8000, api.Warning{WarnType: 1, WarnLevel: 3, ValidFrom: "2026-02-20T10:00:00+01:00", ValidTo: "tonight"}, time.UTC)
+	if v.WarnTypeLabel != warnTypeLabel(1) || v.WarnLevelLabel != warnLevelLabel(3) {
+		t.Errorf("labels = %q, %q", v.WarnTypeLabel, v.WarnLevelLabel)
+	}
+	if v.ValidFromTime == nil || *v.ValidFromTime != time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC) {
+		t.Errorf("validFromTime = %v, want 09:00 UTC", v.ValidFromTime)
+	}
+	if v.ValidToTime != nil {
+		t.Errorf("validToTime = %v, want nil for an unparsable value", v.ValidToTime)
+	}
+}
+
+func TestNewWarningView_inLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	v := newWarningView(8000, api.Warning{ValidTo: "2026-02-20T18:00:00+01:00"}, ny)
+	if v.ValidToTime == nil || v.ValidToTime.Location() != ny || v.ValidToTime.Hour() != 12 {
+		t.Errorf("validToTime = %v, want 12:00 in New York", v.ValidToTime)
+	}
+}
+
+// --- newWeatherView ---
+
+func TestNewWeatherView_timeISO(t *testing.T) {
+	detail := &api.PLZDetail{CurrentWeather: api.CurrentWeather{Time: anchor.UnixMilli(), Icon: 1}}
+	data, err := json.Marshal(newWeatherView(8000, detail, anchor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Time    int64     `json:"time"`
+		TimeISO time.Time `json:"timeIso"`
+		Emoji   string    `json:"iconEmoji"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Time != anchor.UnixMilli() || !got.TimeISO.Equal(anchor) || got.Emoji != api.IconEmoji(1) {
+		t.Errorf("weather JSON = %s", data)
+	}
+}
+
+// --- --json=raw ---
+
+func TestRender_rawDropsDerivedFields(t *testing.T) {
+	views := newWarningViews(8000, []api.Warning{{WarnType: 1, WarnLevel: 3}}, zurich)
+	for _, raw := range []bool{false, true} {
+		flags := rootFlags{format: out.FormatJSON, raw: raw}
+		var buf bytes.Buffer
+		if err := flags.render(&buf, views, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(buf.String(), "warnTypeLabel"); got == raw {
+			t.Errorf("raw = %v: output has labels = %v\n%s", raw, got, buf.String())
+		}
+	}
+}
+
+func TestJSONValue_Set(t *testing.T) {
+	var asJSON, raw bool
+	v := jsonValue{&asJSON, &raw}
+	for _, tc := range []struct {
+		in          string
+		json, isRaw bool
+	}{
+		{"enriched", true, false},
+		{"raw", true, true},
+		{"true", true, false},
+		{"false", false, false},
+	} {
+		if err := v.Set(tc.in); err != nil || asJSON != tc.json || raw != tc.isRaw {
+			t.Errorf("Set(%q) = %v: json %v raw %v; want %v %v", tc.in, err, asJSON, raw, tc.json, tc.isRaw)
+		}
+	}
+	if err := v.Set("xml"); err == nil {
+		t.Error("expected error for unknown mode, got nil")
+	}
+}
+
+// --- warningViews.Table ---
+
+func TestWarningViews_table(t *testing.T) {
+	tbl := newWarningViews(3000, []api.Warning{{WarnType: 2, WarnLevel: 3, Regions: []string{"BE", "FR"}, Headline: "Rain"}}, zurich).Table()
+	if got := strings.Join(tbl.Columns, ","); got != "plz,warnType,warnTypeLabel,warnLevel,warnLevelLabel,validFrom,validTo,regions,headline,body" {
+		t.Errorf("columns = %s", got)
+	}
+	if len(tbl.Rows) != 1 || tbl.Rows[0][0] != "3000" || tbl.Rows[0][7] != "BE;FR" || tbl.Rows[0][4] != warnLevelLabel(3) {
+		t.Errorf("rows = %q", tbl.Rows)
+	}
+}
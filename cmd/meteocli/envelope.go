@@ -0,0 +1,83 @@
+package main
+
+import (
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/geo"
+)
+
+// schemaVersion is the version of the --envelope format and of the schemas
+// printed by the schema command. Adding optional fields keeps the version;
+// removing or changing fields bumps the major version.
+const schemaVersion = "1.0"
+
+// Values of envelope.Source.
+const (
+	envelopeSourceAPI   = "meteoswiss-app-api" // fetched for this run
+	envelopeSourceCache = "cache"              // read from the local cache
+	envelopeSourceNone  = "none"               // no weather data involved
+)
+
+// envelope is the stable wrapper printed with --envelope.
+type envelope struct {
+	SchemaVersion string            `json:"schema_version"`
+	GeneratedAt   time.Time         `json:"generated_at"`
+	Source        string            `json:"source"`
+	Location      *envelopeLocation `json:"location"`
+	Cache         envelopeCache     `json:"cache"`
+	Data          any               `json:"data"`
+}
+
+// envelopeLocation identifies the postal code the data is for. It is null
+// when a command covers several postal codes, as track does.
+type envelopeLocation struct {
+	PLZ  int    `json:"plz"`
+	Name string `json:"name,omitempty"`
+}
+
+// envelopeCache tells how old the data is and whether it came from the
+// local cache.
+type envelopeCache struct {
+	Hit        bool       `json:"hit"`
+	FetchedAt  *time.Time `json:"fetched_at"`
+	AgeSeconds int64      `json:"age_seconds"`
+}
+
+// wrap puts v into an envelope describing the fetches made for it. Times
+// are given in now's location, the --tz zone.
+func (f *rootFlags) wrap(v any, now time.Time) envelope {
+	e := envelope{
+		SchemaVersion: schemaVersion,
+		GeneratedAt:   now,
+		Source:        envelopeSourceNone,
+		Data:          v,
+	}
+	if len(f.fetches) == 0 {
+		return e
+	}
+
+	e.Source = envelopeSourceAPI
+	oldest := f.fetches[0]
+	plzs := map[int]bool{}
+	for _, r := range f.fetches {
+		plzs[r.plz] = true
+		if r.cached {
+			e.Source = envelopeSourceCache
+			e.Cache.Hit = true
+		}
+		if r.fetchedAt.Before(oldest.fetchedAt) {
+			oldest = r
+		}
+	}
+	fetchedAt := oldest.fetchedAt.In(now.Location())
+	e.Cache.FetchedAt = &fetchedAt
+	e.Cache.AgeSeconds = int64(now.Sub(oldest.fetchedAt) / time.Second)
+	if len(plzs) == 1 {
+		plz := f.fetches[0].plz
+		e.Location = &envelopeLocation{PLZ: plz}
+		if loc, ok := geo.ByPLZ(plz); ok {
+			e.Location.Name = loc.Name
+		}
+	}
+	return e
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/cache"
+)
+
+// --- wrap ---
+
+func TestWrap_noFetches(t *testing.T) {
+	var flags rootFlags
+	e := flags.wrap(versionInfo{Version: "1"}, anchor)
+	if e.SchemaVersion != schemaVersion || e.Source != envelopeSourceNone {
+		t.Errorf("envelope = %+v, want schema %s and source none", e, schemaVersion)
+	}
+	if e.Location != nil || e.Cache.FetchedAt != nil {
+		t.Errorf("location = %v, fetched_at = %v; want both nil", e.Location, e.Cache.FetchedAt)
+	}
+}
+
+func TestWrap_singleLocation(t *testing.T) {
+	flags := rootFlags{fetches: []fetchRecord{{plz: 8000, fetchedAt: anchor.Add(-90 * time.Second)}}}
+	e := flags.wrap(nil, anchor)
+	if e.Source != envelopeSourceAPI || e.Cache.Hit {
+		t.Errorf("source = %s, hit = %v; want %s, false", e.Source, e.Cache.Hit, envelopeSourceAPI)
+	}
+	if e.Location == nil || e.Location.PLZ != 8000 || e.Location.Name == "" {
+		t.Errorf("location = %+v, want 8000 with a name", e.Location)
+	}
+	if e.Cache.AgeSeconds != 90 || e.Cache.FetchedAt.Location() != anchor.Location() {
+		t.Errorf("age = %d, want 90", e.Cache.AgeSeconds)
+	}
+}
+
+func TestWrap_severalLocations(t *testing.T) {
+	flags := rootFlags{fetches: []fetchRecord{
+		{plz: 8000, fetchedAt: anchor},
+		{plz: 3000, fetchedAt: anchor.Add(-time.Minute), cached: true},
+	}}
+	e := flags.wrap(nil, anchor)
+	if e.Location != nil {
+		t.Errorf("location = %+v, want nil for several postal codes", e.Location)
+	}
+	if e.Source != envelopeSourceCache || !e.Cache.Hit || e.Cache.AgeSeconds != 60 {
+		t.Errorf("envelope = %+v, want cache source aged by the oldest fetch", e)
+	}
+}
+
+// --- --envelope ---
+
+func TestEnvelope_generatedAtInZone(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := run([]string{"version", "--json", "--envelope", "--tz", "UTC"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var e struct {
+		GeneratedAt string `json:"generated_at"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &e); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if !strings.HasSuffix(e.GeneratedAt, "Z") {
+		t.Errorf("generated_at = %q, want UTC with --tz UTC", e.GeneratedAt)
+	}
+}
+
+func TestEnvelope_promptFromCache(t *testing.T) {
+	stubRefresh(t)
+	_ = cache.Write(detailCacheKey(8000), &api.PLZDetail{CurrentWeather: api.CurrentWeather{Icon: 1}})
+
+	var stdout bytes.Buffer
+	if err := run([]string{"prompt", "--loc", "home", "--json", "--envelope"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		SchemaVersion string `json:"schema_version"`
+		Source        string `json:"source"`
+		Location      struct {
+			PLZ int `json:"plz"`
+		} `json:"location"`
+		Data promptView `json:"data"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if got.SchemaVersion != schemaVersion || got.Source != envelopeSourceCache || got.Location.PLZ != 8000 || got.Data.PLZ != 8000 {
+		t.Errorf("envelope = %+v", got)
+	}
+}
+
+func TestEnvelope_requiresStructuredFormat(t *testing.T) {
+	err := execute([]string{"version", "--envelope"})
+	if err == nil || !strings.Contains(err.Error(), "--envelope") {
+		t.Errorf("error = %v, want an --envelope error", err)
+	}
+	if err := run([]string{"version", "--format", "yaml", "--envelope"}, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Errorf("--format yaml --envelope: %v", err)
+	}
+}
+
+// --- schema ---
+
+func TestSchema_everyCommand(t *testing.T) {
+	for name := range outputSchemas() {
+		var stdout bytes.Buffer
+		if err := run([]string{"schema", name}, &stdout, &bytes.Buffer{}); err != nil {
+			t.Fatalf("schema %s: %v", name, err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+			t.Fatalf("schema %s: invalid JSON: %v", name, err)
+		}
+		if doc["$schema"] == nil || doc["title"] == nil {
+			t.Errorf("schema %s: missing $schema or title", name)
+		}
+	}
+}
+
+func TestSchema_envelopeWrapsData(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := run([]string{"schema", "warnings", "--envelope"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		Properties map[string]struct {
+			Type any `json:"type"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got := doc.Properties["data"].Type; got != "array" {
+		t.Errorf("data type = %v, want array", got)
+	}
+	if _, ok := doc.Properties["schema_version"]; !ok {
+		t.Error("missing schema_version property")
+	}
+}
+
+func TestSchema_unknownCommand(t *testing.T) {
+	if err := execute([]string{"schema", "hourly"}); err == nil {
+		t.Error("expected error for unknown command, got nil")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Process exit codes for commands run with --exit-code.
+const (
+	exitCodeOK       = 0  // verdict negative, e.g. no rain expected
+	exitCodeError    = 2  // the command failed
+	exitCodeRain     = 10 // rain expected according to the nowcast
+	exitCodeFallback = 11 // only the daily forecast was available
+)
+
+// exitError carries a specific process exit code out of execute. A nil err
+// marks a verdict rather than a failure; nothing is printed for it.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// exitCode returns the process exit code for an error returned by execute.
+func exitCode(err error) int {
+	if err == nil {
+		return exitCodeOK
+	}
+	var status *exitError
+	if errors.As(err, &status) {
+		return status.code
+	}
+	return 1
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// explainStep is one step of the reasoning behind a verdict, as shown by
+// --explain. Slot steps carry the slot they describe.
+type explainStep struct {
+	Step   string    `json:"step"`
+	Detail string    `json:"detail"`
+	Slot   *rainSlot `json:"slot,omitempty"`
+}
+
+// Values of explainStep.Step.
+const (
+	stepWindow   = "window"
+	stepSource   = "source"
+	stepTrim     = "trim"
+	stepSlot     = "slot"
+	stepFallback = "fallback"
+	stepVerdict  = "verdict"
+)
+
+// explainWindow describes the look-ahead window and threshold.
+func explainWindow(from, to time.Time, threshold float64) explainStep {
+	return explainStep{
+		Step: stepWindow,
+		Detail: fmt.Sprintf("Window %s–%s (%s), wet at ≥ %.2f mm/h",
+			from.Format("15:04"), to.Format("15:04"), formatMinutes(int(to.Sub(from)/time.Minute)), threshold),
+	}
+}
+
+// explainSource describes where the graph data comes from and which hourly
+// slots were trimmed because 10-minute data already covered them, with times
+// in loc.
+func explainSource(g *api.GraphData, series api.PrecipSeries, loc *time.Location) []explainStep {
+	detail := fmt.Sprintf("Graph data: %d 10-minute slots from %s",
+		len(g.Precipitation10m), time.UnixMilli(g.Start).In(loc).Format("15:04"))
+	if g.StartLowResolution != 0 {
+		detail += fmt.Sprintf(", %d hourly slots from %s",
+			len(g.Precipitation1h), time.UnixMilli(g.StartLowResolution).In(loc).Format("15:04"))
+	}
+	steps := []explainStep{{Step: stepSource, Detail: detail}}
+
+	hourly := 0
+	for _, s := range series {
+		if s.Resolution != api.LowResolution {
+			continue
+		}
+		// Trimming only moves the start, so the slot's end tells where it
+		// originally began.
+		if orig := s.End.Add(-api.LowResolution); s.Start.After(orig) {
+			steps = append(steps, explainStep{
+				Step: stepTrim,
+				Detail: fmt.Sprintf("Hourly slot %s–%s trimmed to %s–%s; the rest is covered by 10-minute data",
+					orig.Format("15:04"), s.End.Format("15:04"), s.Start.Format("15:04"), s.End.Format("15:04")),
+			})
+		}
+		hourly++
+	}
+	if dropped := len(g.Precipitation1h) - hourly; g.StartLowResolution != 0 && dropped > 0 {
+		steps = append(steps, explainStep{
+			Step:   stepTrim,
+			Detail: fmt.Sprintf("%d hourly slot(s) dropped; fully covered by 10-minute data", dropped),
+		})
+	}
+	return steps
+}
+
+// explainSlots describes each slot of the window and whether it counted as
+// wet against threshold.
+func explainSlots(slots []rainSlot, threshold float64) []explainStep {
+	steps := make([]explainStep, 0, len(slots))
+	for i := range slots {
+		s := slots[i]
+		verdict := "dry"
+		if s.MM > 0 && s.IntensityMMH >= threshold {
+			verdict = "wet"
+		}
+		steps = append(steps, explainStep{
+			Step: stepSlot,
+			Detail: fmt.Sprintf("%s–%s  %-3s  %.2f mm = %.2f mm/h (%s) → %s",
+				s.Start.Format("15:04"), s.End.Format("15:04"), s.Resolution, s.MM, s.IntensityMMH, s.Class, verdict),
+			Slot: &s,
+		})
+	}
+	return steps
+}
+
+// explainVerdict records the final verdict of a rain check and where it came
+// from.
+func explainVerdict(r rainResult) explainStep {
+	detail := fmt.Sprintf("Source %s: %s", r.Source, r.Message)
+	if r.Source == rainSourceNowcast {
+		detail = fmt.Sprintf("Source %s: wet %.0f%% of the window, peak %.2f mm/h → %s",
+			r.Source, r.WetFraction*100, r.MaxIntensityMMH, r.Message)
+	}
+	return explainStep{Step: stepVerdict, Detail: detail}
+}
+
+func printExplanation(w io.Writer, steps []explainStep) {
+	fmt.Fprintln(w, "  How this was decided:")
+	for _, s := range steps {
+		indent := "    "
+		if s.Step == stepSlot {
+			indent = "      "
+		}
+		fmt.Fprintf(w, "%s%s\n", indent, s.Detail)
+	}
+	out.Sep(w, 50)
+}
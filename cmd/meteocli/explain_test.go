@@ -0,0 +1,134 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// steps returns the explanation steps of the given kind.
+func steps(all []explainStep, kind string) []explainStep {
+	var s []explainStep
+	for _, e := range all {
+		if e.Step == kind {
+			s = append(s, e)
+		}
+	}
+	return s
+}
+
+// --- checkRain explanation ---
+
+func TestCheckRain_explainListsSlots(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0.5, 0}, []float64{1.2})}
+	result := checkRain(8000, 60, defaultRainThreshold, detail, anchor, units.Metric)
+
+	if result.Explanation[0].Step != stepWindow {
+		t.Errorf("first step = %q, want %q", result.Explanation[0].Step, stepWindow)
+	}
+	slots := steps(result.Explanation, stepSlot)
+	if len(slots) != 4 {
+		t.Fatalf("len(slot steps) = %d, want 4", len(slots))
+	}
+	if slots[1].Slot == nil || slots[1].Slot.MM != 0.5 || !strings.HasSuffix(slots[1].Detail, "wet") {
+		t.Errorf("second slot step = %+v, want the wet 0.5 mm slot", slots[1])
+	}
+	if slots[3].Slot.Resolution != "1h" {
+		t.Errorf("last slot resolution = %q, want 1h", slots[3].Slot.Resolution)
+	}
+	last := result.Explanation[len(result.Explanation)-1]
+	if last.Step != stepVerdict || !strings.Contains(last.Detail, result.Message) {
+		t.Errorf("last step = %+v, want verdict containing %q", last, result.Message)
+	}
+	if len(steps(result.Explanation, stepFallback)) != 0 {
+		t.Error("nowcast result should not mention the daily fallback")
+	}
+}
+
+func TestCheckRain_explainTrimmedHourlySlot(t *testing.T) {
+	g := makeGraph([]float64{0, 0, 0}, []float64{3.0, 1.0})
+	g.StartLowResolution = anchor.UnixMilli() // overlaps the 10-minute data
+	result := checkRain(8000, 120, defaultRainThreshold, &api.PLZDetail{Graph: g}, anchor, units.Metric)
+
+	trims := steps(result.Explanation, stepTrim)
+	if len(trims) != 1 || !strings.Contains(trims[0].Detail, "12:30") {
+		t.Errorf("trim steps = %+v, want one trimming the hourly slot to 12:30", trims)
+	}
+}
+
+func TestCheckRain_explainDroppedHourlySlots(t *testing.T) {
+	// 14 10-minute slots cover 12:00–14:20: the 12:00 and 13:00 hourly slots
+	// are dropped, the 14:00 one is trimmed and the 15:00 one is kept whole.
+	g := makeGraph(make([]float64, 14), []float64{1, 1, 1, 1})
+	g.StartLowResolution = anchor.UnixMilli()
+	result := checkRain(8000, 240, defaultRainThreshold, &api.PLZDetail{Graph: g}, anchor, units.Metric)
+
+	trims := steps(result.Explanation, stepTrim)
+	if len(trims) != 2 {
+		t.Fatalf("trim steps = %+v, want one trimmed and one dropped", trims)
+	}
+	if want := "Hourly slot 14:00–15:00 trimmed to 14:20–15:00"; !strings.HasPrefix(trims[0].Detail, want) {
+		t.Errorf("trim = %q, want %q", trims[0].Detail, want)
+	}
+	if !strings.HasPrefix(trims[1].Detail, "2 hourly slot(s) dropped") {
+		t.Errorf("drop = %q, want 2 dropped", trims[1].Detail)
+	}
+}
+
+func TestCheckRain_explainDailyFallback(t *testing.T) {
+	detail := &api.PLZDetail{Forecast: []api.DayForecast{{DayDate: "2026-02-20", Precipitation: 5.5}}}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+
+	fallback := steps(result.Explanation, stepFallback)
+	if len(fallback) != 2 {
+		t.Fatalf("fallback steps = %+v, want the missing graph and the daily total", fallback)
+	}
+	if !strings.Contains(fallback[1].Detail, "5.5 mm") {
+		t.Errorf("fallback detail = %q, want the daily total", fallback[1].Detail)
+	}
+}
+
+func TestCheckRain_explainGraphEndsBeforeWindow(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0}, nil)}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor.Add(2*time.Hour), units.Metric)
+
+	if f := steps(result.Explanation, stepFallback); len(f) != 1 || !strings.Contains(f[0].Detail, "12:20") {
+		t.Errorf("fallback steps = %+v, want graph ending at 12:20", f)
+	}
+}
+
+func TestCheckRain_explainGraphWithoutStart(t *testing.T) {
+	// 10-minute values without a start time cannot be placed in time.
+	g := makeGraph([]float64{0.5, 0.5}, nil)
+	g.Start = 0
+	detail := &api.PLZDetail{Graph: g, Forecast: []api.DayForecast{{DayDate: "2026-02-20", Precipitation: 5.5}}}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+
+	if result.Source != rainSourceDaily {
+		t.Errorf("Source = %q, want %q", result.Source, rainSourceDaily)
+	}
+	if f := steps(result.Explanation, stepFallback); len(f) == 0 || !strings.Contains(f[0].Detail, "No 10-minute graph data") {
+		t.Errorf("fallback steps = %+v, want the missing graph data first", f)
+	}
+}
+
+// --- findDryWindows explanation ---
+
+func TestFindDryWindows_explainPerTenMinutes(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0.3}, []float64{0.6})}
+	result := findDryWindows(8000, detail, anchor, 10*time.Minute, 80*time.Minute, 0.1)
+
+	slots := steps(result.Explanation, stepSlot)
+	if len(slots) != 3 {
+		t.Fatalf("len(slot steps) = %d, want 3", len(slots))
+	}
+	// The hourly slot is 0.1 mm per 10 minutes and still counts as dry.
+	for i, want := range []string{"dry", "wet", "dry"} {
+		if !strings.HasSuffix(slots[i].Detail, want) {
+			t.Errorf("slot %d = %q, want %s", i, slots[i].Detail, want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/cache"
+)
+
+// fetchRecord notes where the data for one postal code came from, for the
+// --envelope metadata.
+type fetchRecord struct {
+	plz       int
+	fetchedAt time.Time
+	cached    bool
+}
+
+// fetchDetail fetches the detail for plz from the API and stores it in the
+// on-disk cache that prompt reads. Failing to write the cache is not an
+// error; the cache is only an optimisation.
+func (f *rootFlags) fetchDetail(plz int) (*api.PLZDetail, error) {
+	detail, err := api.New().PLZDetail(plz)
+	if err != nil {
+		return nil, err
+	}
+	f.fetches = append(f.fetches, fetchRecord{plz: plz, fetchedAt: time.Now()})
+	_ = cache.Write(detailCacheKey(plz), detail)
+	return detail, nil
+}
+
+// cachedDetail returns the cached detail for plz and when it was fetched,
+// without touching the network.
+func (f *rootFlags) cachedDetail(plz int) (*api.PLZDetail, time.Time, error) {
+	var detail api.PLZDetail
+	at, err := cache.Read(detailCacheKey(plz), &detail)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	f.fetches = append(f.fetches, fetchRecord{plz: plz, fetchedAt: at, cached: true})
+	return &detail, at, nil
+}
+
+func detailCacheKey(plz int) string {
+	return fmt.Sprintf("plz-%d", plz)
+}
@@ -2,16 +2,20 @@ package main
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/a-fgx/meteoswiss-cli/internal/api"
 	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
 )
 
 func newForecastCmd(flags *rootFlags) *cobra.Command {
 	var plz int
 	var days int
+	var oneline bool
+	var showChart bool
 
 	cmd := &cobra.Command{
 		Use:   "forecast",
@@ -20,7 +24,14 @@ func newForecastCmd(flags *rootFlags) *cobra.Command {
   meteocli forecast --zip 8000
 
   # 3-day forecast for Geneva as JSON
-  meteocli forecast --zip 1200 --days 3 --json`,
+  meteocli forecast --zip 1200 --days 3 --json
+
+  # With a chart of the temperature range of each day
+  meteocli forecast --zip 8000 --chart
+
+  # Today in one line, e.g. for a desktop notification
+  notify-send "$(meteocli forecast --zip 8000 --oneline)"`,
+		Args: noArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requirePLZ(plz); err != nil {
 				return err
@@ -29,59 +40,101 @@ func newForecastCmd(flags *rootFlags) *cobra.Command {
 				return fmt.Errorf("--days must be between 1 and 10")
 			}
 
-			client := api.New()
-			detail, err := client.PLZDetail(plz)
+			detail, err := flags.fetchDetail(plz)
 			if err != nil {
 				return err
 			}
 
+			if oneline {
+				return flags.renderOneline(cmd.OutOrStdout(), plz, detail, flags.now(), 1)
+			}
+
 			forecast := detail.Forecast
+			if forecast == nil {
+				forecast = []api.DayForecast{}
+			}
 			if len(forecast) > days {
 				forecast = forecast[:days]
 			}
 
-			if flags.asJSON {
-				return out.PrintJSON(os.Stdout, forecast)
-			}
-
-			printForecast(plz, forecast)
-			return nil
+			return flags.render(cmd.OutOrStdout(), newDayViews(plz, forecast), func(w io.Writer) {
+				now := flags.now()
+				printForecast(w, flags.palette, flags.units, plz, forecast, now)
+				if showChart {
+					printChart(w, temperatureChart(w, flags.palette, flags.units, forecast, now))
+				}
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&plz, "zip", 0, "Swiss postal code (e.g. 8000 for Zurich)")
 	cmd.Flags().IntVar(&days, "days", 7, "number of days to show (1–10)")
+	cmd.Flags().BoolVar(&oneline, "oneline", false, onelineUsage)
+	cmd.Flags().BoolVar(&showChart, "chart", false, "draw the low to high temperature of each day as a band on a shared scale")
+	cmd.MarkFlagsMutuallyExclusive("oneline", "chart")
 	_ = cmd.MarkFlagRequired("zip")
 	return cmd
 }
 
-func printForecast(plz int, forecast []api.DayForecast) {
-	out.Sep(60)
-	fmt.Printf("  %d-day forecast for PLZ %d\n", len(forecast), plz)
-	out.Sep(60)
-	fmt.Printf("  %-12s %-22s %6s %6s  %8s\n", "Date", "Conditions", "Min°C", "Max°C", "Rain mm")
-	out.Sep(60)
+// Table lists one day per row for csv, tsv and markdown.
+func (vs dayViews) Table() out.Table {
+	tbl := out.Table{Columns: []string{
+		"plz", "date", "weekday", "iconDay", "iconDescription",
+		"temperatureMin", "temperatureMax", "precipitation", "precipitationMin", "precipitationMax",
+	}}
+	for _, d := range vs {
+		tbl.Rows = append(tbl.Rows, []string{
+			intCell(d.plz), d.Date, d.Weekday, intCell(d.IconDay), d.IconDescription,
+			numberCell(d.TemperatureMin), numberCell(d.TemperatureMax),
+			numberCell(d.Precipitation), numberCell(d.PrecipitationMin), numberCell(d.PrecipitationMax),
+		})
+	}
+	return tbl
+}
 
+// wetMM is the amount of precipitation, in mm, from which daily and segment
+// totals are highlighted.
+const wetMM = 1.0
+
+// printForecast shows one day per row, labelled relative to now.
+func printForecast(w io.Writer, p out.Palette, u units.System, plz int, forecast []api.DayForecast, now time.Time) {
+	tbl := out.TextTable{
+		Indent: 2,
+		Columns: []out.Column{
+			{Header: "Day"},
+			{Header: "Conditions", Min: 10},
+			{Header: "Min" + u.TempUnit(), Right: true, Drop: 2},
+			{Header: "Max" + u.TempUnit(), Right: true},
+			{Header: "Rain " + u.PrecipUnit(), Right: true, Drop: 1},
+		},
+	}
 	for _, day := range forecast {
-		emoji := api.IconEmoji(day.IconDay)
-		desc := api.IconDescription(day.IconDay)
-		label := fmt.Sprintf("%s (%s)", desc, emoji)
-		fmt.Printf("  %-12s %-22s %6.1f %6.1f  %8.1f\n",
-			day.DayDate,
-			truncate(label, 22),
-			day.TemperatureMin,
-			day.TemperatureMax,
-			day.Precipitation,
-		)
+		tbl.Rows = append(tbl.Rows, []string{
+			forecastDayLabel(day, now),
+			fmt.Sprintf("%s (%s)", api.IconDescription(day.IconDay), api.IconEmoji(day.IconDay)),
+			p.Temp(u.FormatTemp(day.TemperatureMin), day.TemperatureMin),
+			p.Temp(u.FormatTemp(day.TemperatureMax), day.TemperatureMax),
+			p.Rain(u.FormatPrecip(day.Precipitation), day.Precipitation >= wetMM),
+		})
 	}
-	out.Sep(60)
-}
+	width := out.Width(w)
+	header, rows, used := tbl.Layout(w)
+	strip := artStrip(w, p, u, forecast, now)
+	sep := min(max(60, used, linesWidth(w, strip)), width)
 
-// truncate shortens s to at most n runes.
-func truncate(s string, n int) string {
-	runes := []rune(s)
-	if len(runes) <= n {
-		return s
+	out.Sep(w, sep)
+	fmt.Fprintf(w, "  %d-day forecast for PLZ %d\n", len(forecast), plz)
+	out.Sep(w, sep)
+	fmt.Fprintln(w, header)
+	out.Sep(w, sep)
+	for _, row := range rows {
+		fmt.Fprintln(w, row)
+	}
+	out.Sep(w, sep)
+	if len(strip) > 0 {
+		for _, l := range strip {
+			fmt.Fprintln(w, l)
+		}
+		out.Sep(w, sep)
 	}
-	return string(runes[:n-1]) + "…"
 }
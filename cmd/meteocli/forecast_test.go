@@ -1,47 +1,69 @@
 package main
 
-import "testing"
+import (
+	"bytes"
+	"strings"
+	"testing"
 
-func TestTruncate_shortString(t *testing.T) {
-	got := truncate("Sunny", 10)
-	if got != "Sunny" {
-		t.Errorf("truncate(%q, 10) = %q, want %q", "Sunny", got, "Sunny")
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// --- dayViews.Table ---
+
+func TestDayViews_table(t *testing.T) {
+	views := newDayViews(8000, []api.DayForecast{
+		{DayDate: "2026-02-20", IconDay: 35, TemperatureMin: -1.5, TemperatureMax: 4, Precipitation: 2.25},
+	})
+	var buf bytes.Buffer
+	r, _ := out.NewRenderer(out.FormatCSV, nil)
+	if err := r.Render(&buf, views); err != nil {
+		t.Fatal(err)
+	}
+	want := "plz,date,weekday,iconDay,iconDescription,temperatureMin,temperatureMax,precipitation,precipitationMin,precipitationMax\n" +
+		"8000,2026-02-20,Friday,35," + api.IconDescription(35) + ",-1.5,4,2.25,0,0\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
 	}
-}
 
-func TestTruncate_exactLength(t *testing.T) {
-	s := "1234567890"
-	got := truncate(s, 10)
-	if got != s {
-		t.Errorf("truncate(%q, 10) = %q, want %q", s, got, s)
+	buf.Reset()
+	_ = r.Render(&buf, newDayViews(8000, nil))
+	if !strings.HasPrefix(buf.String(), "plz,date,") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("CSV(no days) = %q, want the header only", buf.String())
 	}
 }
 
-func TestTruncate_overLength(t *testing.T) {
-	got := truncate("Heavy thunderstorm expected tonight", 10)
-	runes := []rune(got)
-	if len(runes) != 10 {
-		t.Errorf("truncate result has %d runes, want 10: %q", len(runes), got)
-	}
-	// Must end with the ellipsis character.
-	if runes[len(runes)-1] != '…' {
-		t.Errorf("truncated string %q should end with '…'", got)
+// --- printForecast ---
+
+func TestPrintForecast_dayLabels(t *testing.T) {
+	days := []api.DayForecast{{DayDate: "2026-02-20"}, {DayDate: "20260221"}, {DayDate: "2026-02-22"}}
+	var buf bytes.Buffer
+	printForecast(&buf, out.Palette{}, units.Metric, 8000, days, anchor)
+	for _, want := range []string{"  Today ", "  Tomorrow ", "  Sun 22 Feb "} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, buf.String())
+		}
 	}
 }
 
-func TestTruncate_unicode(t *testing.T) {
-	// Emoji are multi-byte but single rune; truncation should count runes.
-	s := "☀️ Sunny day ahead in Zurich"
-	got := truncate(s, 8)
-	runes := []rune(got)
-	if len(runes) != 8 {
-		t.Errorf("truncate unicode result has %d runes, want 8: %q", len(runes), got)
+func TestPrintForecast_colourKeepsColumns(t *testing.T) {
+	theme, _ := out.LookupTheme("")
+	days := []api.DayForecast{
+		{DayDate: "2026-02-20", IconDay: 1, TemperatureMin: -3, TemperatureMax: 4, Precipitation: 0},
+		{DayDate: "2026-02-21", IconDay: 1, TemperatureMin: 12, TemperatureMax: 31, Precipitation: 8.5},
 	}
-}
+	var plain, coloured bytes.Buffer
+	printForecast(&plain, out.Palette{}, units.Metric, 8000, days, anchor)
+	printForecast(&coloured, out.NewPalette(theme, true), units.Metric, 8000, days, anchor)
 
-func TestTruncate_empty(t *testing.T) {
-	got := truncate("", 10)
-	if got != "" {
-		t.Errorf("truncate(%q, 10) = %q, want %q", "", got, "")
+	if plain.String() == coloured.String() || !strings.Contains(coloured.String(), "\x1b[") {
+		t.Fatal("palette did not colour the forecast")
+	}
+	pl, cl := strings.Split(plain.String(), "\n"), strings.Split(coloured.String(), "\n")
+	for i := range pl {
+		if out.StringWidth(pl[i]) != out.StringWidth(cl[i]) {
+			t.Errorf("line %d width %d, coloured %d:\n%q\n%q", i, out.StringWidth(pl[i]), out.StringWidth(cl[i]), pl[i], cl[i])
+		}
 	}
 }
@@ -6,6 +6,6 @@ import (
 
 func main() {
 	if err := execute(os.Args[1:]); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
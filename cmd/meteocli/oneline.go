@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/geo"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// onelineUsage is the help text of --oneline, shared by the commands that
+// have it.
+const onelineUsage = "print a single summary line: current weather, today's forecast and the most severe active warning"
+
+// onelineView is the result of --oneline: one dense line for login banners
+// and desktop notifications, such as
+//
+//	Zürich 12.3°C ⛅ · today 8/15°C 2.1mm · ⚠ Wind L3 until 18:00
+type onelineView struct {
+	PLZ  int    `json:"plz"`
+	Text string `json:"text"`
+}
+
+// renderOneline writes the summary line for plz. Only warnings of at least
+// minLevel are considered.
+func (f *rootFlags) renderOneline(w io.Writer, plz int, detail *api.PLZDetail, now time.Time, minLevel int) error {
+	view := onelineView{PLZ: plz, Text: onelineText(out.Palette{}, f.units, plz, detail, now, minLevel)}
+	return f.render(w, view, func(w io.Writer) {
+		fmt.Fprintln(w, onelineText(f.palette, f.units, plz, detail, now, minLevel))
+	})
+}
+
+// onelineText joins the current weather, the first forecast day and the
+// most severe warning active at now with " · ".
+func onelineText(p out.Palette, u units.System, plz int, detail *api.PLZDetail, now time.Time, minLevel int) string {
+	name := fmt.Sprintf("PLZ %d", plz)
+	if l, ok := geo.ByPLZ(plz); ok {
+		name = l.Name
+	}
+	cw := detail.CurrentWeather
+	parts := []string{fmt.Sprintf("%s %s %s",
+		name, p.Temp(u.FormatTemp(cw.Temperature)+u.TempUnit(), cw.Temperature), api.IconEmoji(cw.Icon))}
+
+	if len(detail.Forecast) > 0 {
+		day := detail.Forecast[0]
+		parts = append(parts, fmt.Sprintf("%s %s/%s%s %s",
+			onelineDay(day, now),
+			p.Temp(fmt.Sprintf("%.0f", u.Temp(day.TemperatureMin)), day.TemperatureMin),
+			p.Temp(fmt.Sprintf("%.0f", u.Temp(day.TemperatureMax)), day.TemperatureMax),
+			u.TempUnit(),
+			p.Rain(u.FormatPrecip(day.Precipitation)+u.PrecipUnit(), day.Precipitation >= wetMM)))
+	}
+
+	if warn, ok := mostSevereWarning(activeWarnings(detail.Warnings, now, now), minLevel); ok {
+		s := fmt.Sprintf("⚠ %s L%d", warnTypeLabel(warn.WarnType), warn.WarnLevel)
+		if until, err := time.Parse(time.RFC3339, warn.ValidTo); err == nil {
+			s += " until " + untilLabel(until, now)
+		}
+		parts = append(parts, p.Level(s, warn.WarnLevel))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// onelineDay labels a forecast day in lower case when it is relative to
+// now, e.g. "today", and as "Tue 20 Oct" otherwise.
+func onelineDay(day api.DayForecast, now time.Time) string {
+	label := forecastDayLabel(day, now)
+	if date, err := day.Date(); err == nil {
+		if n := date.DaysSince(api.DateOf(now)); n >= -1 && n <= 1 {
+			return strings.ToLower(label)
+		}
+	}
+	return label
+}
+
+// untilLabel formats the end of a warning in now's location: the time alone
+// when it ends today, with the weekday otherwise.
+func untilLabel(t, now time.Time) string {
+	t = t.In(now.Location())
+	if api.DateOf(t) == api.DateOf(now) {
+		return t.Format("15:04")
+	}
+	return t.Format("Mon 15:04")
+}
+
+// mostSevereWarning returns the warning with the highest level of at least
+// minLevel, the first one on ties.
+func mostSevereWarning(warnings []api.Warning, minLevel int) (api.Warning, bool) {
+	var worst api.Warning
+	found := false
+	for _, w := range warnings {
+		if w.WarnLevel >= minLevel && (!found || w.WarnLevel > worst.WarnLevel) {
+			worst, found = w, true
+		}
+	}
+	return worst, found
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// onelineDetail is Zurich on 20 February 2026 with a level 3 wind warning
+// until 18:00 and a level 2 rain warning.
+func onelineDetail() *api.PLZDetail {
+	return &api.PLZDetail{
+		CurrentWeather: api.CurrentWeather{Icon: 3, Temperature: 12.34},
+		Forecast:       []api.DayForecast{{DayDate: "2026-02-20", TemperatureMin: 8.2, TemperatureMax: 14.6, Precipitation: 2.1}},
+		Warnings: []api.Warning{
+			{WarnType: 2, WarnLevel: 2, ValidFrom: "2026-02-20T06:00:00+01:00", ValidTo: "2026-02-21T06:00:00+01:00"},
+			{WarnType: 0, WarnLevel: 3, ValidFrom: "2026-02-20T08:00:00+01:00", ValidTo: "2026-02-20T18:00:00+01:00"},
+			{WarnType: 1, WarnLevel: 4, ValidFrom: "2026-02-21T12:00:00+01:00", ValidTo: "2026-02-21T18:00:00+01:00"},
+		},
+	}
+}
+
+// onelineNow is noon on 20 February 2026 in Zurich.
+var onelineNow = time.Date(2026, 2, 20, 12, 0, 0, 0, zurich)
+
+// --- onelineText ---
+
+func TestOnelineText(t *testing.T) {
+	got := onelineText(out.Palette{}, units.Metric, 8000, onelineDetail(), onelineNow, 1)
+	want := "Zürich 12.3°C " + api.IconEmoji(3) + " · today 8/15°C 2.1mm · ⚠ Wind L3 until 18:00"
+	if got != want {
+		t.Errorf("onelineText() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestOnelineText_minLevelAndUnits(t *testing.T) {
+	got := onelineText(out.Palette{}, units.Imperial, 8000, onelineDetail(), onelineNow, 4)
+	if !strings.Contains(got, "54.2°F") || !strings.Contains(got, "today 47/58°F 0.08in") {
+		t.Errorf("onelineText(imperial) = %q", got)
+	}
+	if strings.Contains(got, "⚠") {
+		t.Errorf("onelineText(min level 4) = %q, want no warning: the level 4 one is not active yet", got)
+	}
+}
+
+func TestOnelineText_sparseData(t *testing.T) {
+	got := onelineText(out.Palette{}, units.Metric, 9999, &api.PLZDetail{CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: -2}}, onelineNow, 1)
+	if want := "PLZ 9999 -2.0°C " + api.IconEmoji(1); got != want {
+		t.Errorf("onelineText() = %q, want %q", got, want)
+	}
+}
+
+func TestUntilLabel(t *testing.T) {
+	if got := untilLabel(onelineNow.Add(6*time.Hour), onelineNow); got != "18:00" {
+		t.Errorf("same day: %q, want 18:00", got)
+	}
+	if got := untilLabel(onelineNow.Add(24*time.Hour), onelineNow.In(time.UTC)); got != "Sat 11:00" {
+		t.Errorf("next day in UTC: %q, want Sat 11:00", got)
+	}
+}
+
+// --- renderOneline ---
+
+func TestRenderOneline_formats(t *testing.T) {
+	var buf bytes.Buffer
+	flags := rootFlags{format: out.FormatText, ascii: true}
+	if err := flags.renderOneline(&buf, 8000, onelineDetail(), onelineNow, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); strings.Count(got, "\n") != 1 || got != out.ASCII(got) || !strings.Contains(got, "Wind L3 until 18:00") {
+		t.Errorf("--ascii --oneline = %q, want a single ASCII line", got)
+	}
+
+	buf.Reset()
+	flags = rootFlags{format: out.FormatJSON}
+	if err := flags.renderOneline(&buf, 8000, onelineDetail(), onelineNow, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.Contains(got, `"plz": 8000`) || !strings.Contains(got, `"text": "Zürich 12.3°C`) {
+		t.Errorf("--json --oneline = %s", got)
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/cache"
+	"github.com/a-fgx/meteoswiss-cli/internal/config"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// promptView is the structured result for the prompt command.
+type promptView struct {
+	PLZ         int        `json:"plz"`
+	Icon        int        `json:"icon"`
+	Temperature float64    `json:"temperature"`
+	RainSoon    bool       `json:"rain_soon"`
+	FetchedAt   time.Time  `json:"fetched_at"`
+	Stale       bool       `json:"stale"`
+	Units       *unitsInfo `json:"units,omitempty"`
+}
+
+func (v promptView) inUnits(u units.System) any {
+	v.Temperature = u.Temp(v.Temperature)
+	v.Units = newUnitsInfo(u)
+	return v
+}
+
+// promptRefreshLock is how long a background refresh may take before another
+// prompt may start a new one.
+const promptRefreshLock = 2 * time.Minute
+
+// startRefresh launches the background refresh; tests replace it.
+var startRefresh = spawnRefresh
+
+func newPromptCmd(flags *rootFlags) *cobra.Command {
+	var loc string
+	var maxAge time.Duration
+	var refresh bool
+
+	cmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print a short weather segment for shell prompts, from cached data only",
+		Long: `Print a short weather segment (icon, temperature and ☔ when rain is
+expected in the next 30 minutes) for shell prompts such as starship or
+powerlevel10k.
+
+prompt never waits for the network. It answers from the data cached by the
+last meteocli command for that location; when the data is older than
+--max-age, or there is none yet, it starts a refresh in the background and
+the next prompt picks up the new data. Without cached data it prints nothing.`,
+		Example: `  # Segment for the "home" location from the config file
+  meteocli prompt --loc home
+
+  # starship.toml
+  [custom.weather]
+  command = "meteocli prompt --loc home"
+  when = true`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			plz, err := cfg.Resolve(loc)
+			if err != nil {
+				return err
+			}
+			if err := requirePLZ(plz); err != nil {
+				return err
+			}
+
+			if refresh {
+				defer cache.Unlock(detailCacheKey(plz))
+				_, err := flags.fetchDetail(plz)
+				return err
+			}
+
+			now := flags.now()
+			detail, fetchedAt, err := flags.cachedDetail(plz)
+			if err != nil || now.Sub(fetchedAt) > maxAge {
+				// Missing, unreadable or old: refresh for the next prompt.
+				refreshInBackground(plz)
+			}
+			if err != nil {
+				return nil
+			}
+
+			view := promptSegment(plz, detail, fetchedAt, now, maxAge)
+			return flags.render(cmd.OutOrStdout(), view, func(w io.Writer) {
+				fmt.Fprintln(w, view.text(flags.units))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&loc, "loc", "", "location name from the config file, or a Swiss postal code")
+	cmd.Flags().DurationVar(&maxAge, "max-age", 10*time.Minute, "refresh cached data in the background once it is older than this")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "fetch and cache the data in the foreground, printing nothing")
+	_ = cmd.Flags().MarkHidden("refresh")
+	_ = cmd.MarkFlagRequired("loc")
+	return cmd
+}
+
+// promptSegment builds the prompt view from cached data.
+func promptSegment(plz int, detail *api.PLZDetail, fetchedAt, now time.Time, maxAge time.Duration) promptView {
+	rain := checkRain(plz, 30, defaultRainThreshold, detail, now, units.Metric)
+	return promptView{
+		PLZ:         plz,
+		Icon:        detail.CurrentWeather.Icon,
+		Temperature: detail.CurrentWeather.Temperature,
+		RainSoon:    rain.RainExpected && rain.Source == rainSourceNowcast,
+		FetchedAt:   fetchedAt,
+		Stale:       now.Sub(fetchedAt) > maxAge,
+	}
+}
+
+// text renders the segment in the units u, e.g. "☀️ 14°" or "🌧️ 9° ☔".
+func (v promptView) text(u units.System) string {
+	s := fmt.Sprintf("%s %.0f°", api.IconEmoji(v.Icon), u.Temp(v.Temperature))
+	if v.RainSoon {
+		s += " ☔"
+	}
+	return s
+}
+
+// refreshInBackground starts a detached refresh for plz unless one is
+// already running.
+func refreshInBackground(plz int) {
+	release, ok := cache.Lock(detailCacheKey(plz), promptRefreshLock)
+	if !ok {
+		return
+	}
+	if err := startRefresh(plz); err != nil {
+		release()
+	}
+}
+
+// spawnRefresh runs "meteocli prompt --refresh" for plz as a detached
+// process that outlives the prompt. The child removes the lock when done.
+func spawnRefresh(plz int) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	c := exec.Command(exe, "prompt", "--loc", fmt.Sprint(plz), "--refresh")
+	c.SysProcAttr = detachedProcAttr()
+	if err := c.Start(); err != nil {
+		return err
+	}
+	return c.Process.Release()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/cache"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// stubRefresh points the cache and config at temporary directories, writes
+// a config with a "home" location, and records background refreshes instead
+// of starting processes.
+func stubRefresh(t *testing.T) *[]int {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	cfg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", cfg)
+	_ = os.MkdirAll(filepath.Join(cfg, "meteocli"), 0o755)
+	_ = os.WriteFile(filepath.Join(cfg, "meteocli", "config.json"), []byte(`{"locations": {"home": 8000}}`), 0o644)
+
+	var started []int
+	orig := startRefresh
+	startRefresh = func(plz int) error {
+		started = append(started, plz)
+		return nil
+	}
+	t.Cleanup(func() { startRefresh = orig })
+	return &started
+}
+
+// --- prompt ---
+
+func TestPrompt_freshCache(t *testing.T) {
+	started := stubRefresh(t)
+	detail := &api.PLZDetail{CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: 14.4}}
+	if err := cache.Write(detailCacheKey(8000), detail); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := run([]string{"prompt", "--loc", "home"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := api.IconEmoji(1) + " 14°\n"; stdout.String() != want {
+		t.Errorf("output = %q, want %q", stdout.String(), want)
+	}
+	if len(*started) != 0 {
+		t.Errorf("refresh started for fresh data: %v", *started)
+	}
+}
+
+func TestPrompt_staleCacheRefreshesOnce(t *testing.T) {
+	started := stubRefresh(t)
+	_ = cache.Write(detailCacheKey(8000), &api.PLZDetail{})
+
+	for i := 0; i < 2; i++ {
+		var stdout bytes.Buffer
+		if err := run([]string{"prompt", "--loc", "8000", "--max-age", "0s"}, &stdout, &bytes.Buffer{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stdout.Len() == 0 {
+			t.Error("stale data should still be printed")
+		}
+	}
+	if len(*started) != 1 || (*started)[0] != 8000 {
+		t.Errorf("refreshes = %v, want a single one for 8000 while the lock is held", *started)
+	}
+}
+
+func TestPrompt_noCachePrintsNothing(t *testing.T) {
+	started := stubRefresh(t)
+	var stdout bytes.Buffer
+	if err := run([]string{"prompt", "--loc", "home"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("output = %q, want nothing", stdout.String())
+	}
+	if len(*started) != 1 {
+		t.Errorf("refreshes = %v, want one", *started)
+	}
+}
+
+func TestPrompt_unknownLocation(t *testing.T) {
+	stubRefresh(t)
+	if err := run([]string{"prompt", "--loc", "cabin"}, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+		t.Error("expected error for unknown location, got nil")
+	}
+}
+
+func TestPromptSegment_graphWithoutStart(t *testing.T) {
+	g := makeGraph([]float64{0, 0.5, 0}, nil)
+	g.Start = 0
+	detail := &api.PLZDetail{CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: 9}, Graph: g}
+	if v := promptSegment(8000, detail, anchor, anchor.Add(time.Minute), 10*time.Minute); v.RainSoon {
+		t.Errorf("promptSegment() = %+v, want no rain without placeable graph data", v)
+	}
+}
+
+func TestPromptSegment_rainSoon(t *testing.T) {
+	detail := &api.PLZDetail{
+		CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: 9},
+		Graph:          makeGraph([]float64{0, 0.5, 0}, nil),
+	}
+	v := promptSegment(8000, detail, anchor, anchor.Add(time.Minute), 10*time.Minute)
+	if !v.RainSoon || v.Stale {
+		t.Errorf("promptSegment() = %+v, want rain soon and not stale", v)
+	}
+	if want := api.IconEmoji(1) + " 9° ☔"; v.text(units.Metric) != want {
+		t.Errorf("text() = %q, want %q", v.text(units.Metric), want)
+	}
+}
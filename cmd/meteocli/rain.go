@@ -1,27 +1,103 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/a-fgx/meteoswiss-cli/internal/api"
 	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
 )
 
-// rainResult is the structured result for the rain command.
+// rainResult is the structured result for the rain command. MaxRainMM is the
+// largest amount falling in any 10-minute step of the window (or today's total
+// when only the daily forecast is available); MaxIntensityMMH is the peak rate
+// and TotalMM the amount accumulated over the whole window. WetFraction is the
+// share of the window, by time, with any precipitation.
 type rainResult struct {
-	PLZ           int     `json:"plz"`
-	WithinMinutes int     `json:"within_minutes"`
-	RainExpected  bool    `json:"rain_expected"`
-	MaxRainMM     float64 `json:"max_rain_mm"`
-	Message       string  `json:"message"`
+	PLZ             int           `json:"plz"`
+	At              *time.Time    `json:"at,omitempty"`
+	WithinMinutes   int           `json:"within_minutes"`
+	ThresholdMMH    float64       `json:"threshold_mm_h"`
+	RainExpected    bool          `json:"rain_expected"`
+	Source          string        `json:"source"`
+	PrecipType      string        `json:"precip_type,omitempty"`
+	IntensityClass  string        `json:"intensity_class,omitempty"`
+	MaxRainMM       float64       `json:"max_rain_mm"`
+	MaxIntensityMMH float64       `json:"max_intensity_mm_h"`
+	TotalMM         float64       `json:"total_mm"`
+	WetFraction     float64       `json:"wet_fraction"`
+	RainStart       *time.Time    `json:"rain_start,omitempty"`
+	RainStop        *time.Time    `json:"rain_stop,omitempty"`
+	PeakAt          *time.Time    `json:"peak_at,omitempty"`
+	DryMinutes      int           `json:"dry_minutes"`
+	Message         string        `json:"message"`
+	Timeline        []rainSlot    `json:"timeline,omitempty"`
+	Explanation     []explainStep `json:"explanation,omitempty"`
+
+	// warnLevel is the highest active warning level, for the status-bar
+	// formats.
+	warnLevel int
+}
+
+// Table lists one precipitation slot of the timeline per row for csv, tsv
+// and markdown. It has no rows when only the daily forecast was available.
+func (r rainResult) Table() out.Table {
+	tbl := out.Table{Columns: []string{"plz", "start", "end", "resolution", "mm", "intensity_mm_h", "class"}}
+	for _, s := range r.Timeline {
+		tbl.Rows = append(tbl.Rows, []string{
+			intCell(r.PLZ), timeCell(&s.Start), timeCell(&s.End), s.Resolution,
+			numberCell(s.MM), numberCell(s.IntensityMMH), s.Class,
+		})
+	}
+	return tbl
+}
+
+// rainWindows is the result of the rain command with --windows.
+type rainWindows []rainResult
+
+// Table lists one window per row for csv, tsv and markdown.
+func (rs rainWindows) Table() out.Table {
+	tbl := out.Table{Columns: []string{
+		"plz", "within_minutes", "rain_expected", "source", "max_rain_mm", "max_intensity_mm_h",
+		"total_mm", "wet_fraction", "rain_start", "rain_stop", "dry_minutes",
+	}}
+	for _, r := range rs {
+		tbl.Rows = append(tbl.Rows, []string{
+			intCell(r.PLZ), intCell(r.WithinMinutes), boolCell(r.RainExpected), r.Source,
+			numberCell(r.MaxRainMM), numberCell(r.MaxIntensityMMH), numberCell(r.TotalMM), numberCell(r.WetFraction),
+			timeCell(r.RainStart), timeCell(r.RainStop), intCell(r.DryMinutes),
+		})
+	}
+	return tbl
+}
+
+// rainSlot is a single precipitation slot of the graph data.
+type rainSlot struct {
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Resolution   string    `json:"resolution"`
+	MM           float64   `json:"mm"`
+	IntensityMMH float64   `json:"intensity_mm_h"`
+	Class        string    `json:"class"`
 }
 
 func newRainCmd(flags *rootFlags) *cobra.Command {
 	var plz int
 	var within int
+	var windows []time.Duration
+	var threshold float64
+	var quiet bool
+	var explain bool
+	var showChart bool
+	var at string
 
 	cmd := &cobra.Command{
 		Use:   "rain",
@@ -29,146 +105,553 @@ func newRainCmd(flags *rootFlags) *cobra.Command {
 		Example: `  # Rain check with the default 30-minute window for Zurich
   meteocli rain --zip 8000
 
-  # Rain check for the next 60 minutes in Bern
-  meteocli rain --zip 3000 --within 60
+  # Rain check for the next 90 minutes in Bern
+  meteocli rain --zip 3000 --within 90m
+
+  # Evening commute, checked in the morning
+  meteocli rain --zip 8000 --at 17:30 --within 1h
+  meteocli rain --zip 8000 --at "tomorrow 07:30"
+
+  # Ignore anything lighter than moderate rain
+  meteocli rain --zip 8000 --threshold 2.5
+
+  # Totals for several windows from a single fetch
+  meteocli rain --zip 8000 --windows 30m,1h,3h,6h
+
+  # In a shell script: exit status 0 when dry, 10 when rain is expected
+  if meteocli rain --zip 8000 -q; then echo "Go for a walk"; fi
+
+  # Rain intensity over the next hours as a sparkline
+  meteocli rain --zip 8000 --within 2h --chart
+
+  # Show which forecast slots led to the verdict
+  meteocli rain --zip 8000 --within 60 --explain
 
   # As JSON
-  meteocli rain --zip 8000 --json`,
+  meteocli rain --zip 8000 --json
+
+  # tmux status line segment
+  meteocli rain --zip 8000 --within 1h --format tmux`,
+		Args: noArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requirePLZ(plz); err != nil {
 				return err
 			}
 			if within < 1 || within > 1440 {
-				return fmt.Errorf("--within must be between 1 and 1440 minutes")
+				return fmt.Errorf("--within must be between 1m and 24h")
+			}
+			if threshold < 0 {
+				return fmt.Errorf("--threshold must not be negative")
+			}
+			for _, w := range windows {
+				if w < time.Minute || w > 24*time.Hour {
+					return fmt.Errorf("--windows entries must be between 1m and 24h, got %s", w)
+				}
+			}
+
+			now, err := parseAt(at, flags.now(), flags.loc)
+			if err != nil {
+				return fmt.Errorf("--at: %w", err)
 			}
 
-			client := api.New()
-			detail, err := client.PLZDetail(plz)
+			detail, err := flags.fetchDetail(plz)
 			if err != nil {
 				return err
 			}
 
-			result := checkRain(plz, within, detail, time.Now())
+			if len(windows) > 0 {
+				results := make(rainWindows, 0, len(windows))
+				for _, w := range windows {
+					r := checkRain(plz, int(w/time.Minute), threshold, detail, now, flags.units)
+					r.At = shiftedAt(at, now, flags.loc)
+					if !explain {
+						r.Explanation = nil
+					}
+					results = append(results, r)
+				}
+				if !quiet {
+					err := flags.render(cmd.OutOrStdout(), results, func(w io.Writer) {
+						printRainWindows(w, flags.palette, flags.units, plz, results)
+						if showChart {
+							printChart(w, rainChart(w, flags.palette, flags.units, detail.Graph, now, slices.Max(windows), threshold, chartMark(at, now)))
+						}
+						for _, r := range results {
+							if len(r.Explanation) > 0 {
+								fmt.Fprintf(w, "  Window %s\n", formatMinutes(r.WithinMinutes))
+								printExplanation(w, r.Explanation)
+							}
+						}
+					})
+					if err != nil {
+						return err
+					}
+				}
+				return rainExitStatus(flags, results[0])
+			}
+
+			result := checkRain(plz, within, threshold, detail, now, flags.units)
+			result.At = shiftedAt(at, now, flags.loc)
+			result.warnLevel = maxWarnLevel(activeWarnings(detail.Warnings, now, now.Add(time.Duration(within)*time.Minute)))
+			if !explain {
+				result.Explanation = nil
+			}
 
-			if flags.asJSON {
-				return out.PrintJSON(os.Stdout, result)
+			if !quiet {
+				err := flags.render(cmd.OutOrStdout(), result, func(w io.Writer) {
+					printRainCheck(w, flags.palette, flags.units, result)
+					if showChart {
+						printChart(w, rainChart(w, flags.palette, flags.units, detail.Graph, now, time.Duration(within)*time.Minute, threshold, chartMark(at, now)))
+					}
+					if len(result.Explanation) > 0 {
+						printExplanation(w, result.Explanation)
+					}
+				})
+				if err != nil {
+					return err
+				}
 			}
-			printRainCheck(result)
-			return nil
+			return rainExitStatus(flags, result)
 		},
 	}
 
 	cmd.Flags().IntVar(&plz, "zip", 0, "Swiss postal code (e.g. 8000 for Zurich)")
-	cmd.Flags().IntVar(&within, "within", 30, "look-ahead window in minutes (1–1440)")
+	cmd.Flags().Var(newMinutesValue(30, &within), "within", "look-ahead window, e.g. 30, 90m or 2h (up to 24h)")
+	cmd.Flags().StringVar(&at, "at", "", `start of the window in the --tz zone, e.g. "17:30", "tomorrow 07:30", "2026-10-17T18:00" or "+3h" (default now)`)
+	cmd.Flags().Float64Var(&threshold, "threshold", defaultRainThreshold, "lowest intensity in mm/h that counts as rain")
+	cmd.Flags().BoolVar(&flags.exitCode, "exit-code", false, "exit 0 when dry, 10 when rain is expected, 11 when only the daily forecast was available, 2 on error")
+	cmd.Flags().VarP(quietValue{&quiet, &flags.exitCode}, "quiet", "q", "print nothing; implies --exit-code")
+	cmd.Flags().Lookup("quiet").NoOptDefVal = "true"
+	cmd.Flags().BoolVar(&explain, "explain", false, "show the forecast slots and rules that led to the verdict")
+	cmd.Flags().BoolVar(&showChart, "chart", false, "draw the precipitation intensity around the window as a sparkline with a time axis")
+	cmd.Flags().DurationSliceVar(&windows, "windows", nil, "report several look-ahead windows at once (e.g. 30m,1h,3h,6h)")
 	_ = cmd.MarkFlagRequired("zip")
+	cmd.MarkFlagsMutuallyExclusive("within", "windows")
 	return cmd
 }
 
+// chartMark labels the start of the window in the rain chart: "now", or
+// the time given with --at.
+func chartMark(at string, start time.Time) string {
+	if at == "" {
+		return "now"
+	}
+	return start.Format("15:04")
+}
+
+// quietValue is the --quiet flag. Quiet mode only makes sense if the
+// verdict is in the exit status, so it turns on --exit-code as soon as it is
+// parsed: errors found before the command runs, such as an unknown --tz,
+// then exit with exitCodeError too.
+type quietValue struct {
+	quiet    *bool
+	exitCode *bool
+}
+
+func (v quietValue) String() string {
+	if v.quiet == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*v.quiet)
+}
+
+func (v quietValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*v.quiet = b
+	if b {
+		*v.exitCode = true
+	}
+	return nil
+}
+
+func (v quietValue) Type() string { return "bool" }
+
+// defaultRainThreshold is the lowest intensity (mm/h) that counts as rain;
+// anything below is reported as trace precipitation.
+const defaultRainThreshold = 0.2
+
+// dailyThresholdMM is the smallest daily total, in mm, that can hold an hour
+// of rain at threshold mm/h. With only the day's total known, a day with less
+// cannot have a wet hourly slot however the rain is spread, so the daily
+// fallback reports rain from this amount on.
+func dailyThresholdMM(threshold float64) float64 {
+	return threshold * loInterval.Hours()
+}
+
+// Values of rainResult.Source.
+const (
+	rainSourceNowcast = "nowcast" // graph data covered the window
+	rainSourceDaily   = "daily"   // only today's daily total was available
+	rainSourceNone    = "none"    // no precipitation data at all
+)
+
+// rainExitStatus maps a rain verdict to the process exit status when
+// --exit-code is in effect. With --windows the first window decides.
+func rainExitStatus(flags *rootFlags, r rainResult) error {
+	if !flags.exitCode {
+		return nil
+	}
+	switch {
+	case r.Source == rainSourceNone:
+		return &exitError{code: exitCodeError, err: errors.New(r.Message)}
+	case r.Source == rainSourceDaily:
+		return &exitError{code: exitCodeFallback}
+	case r.RainExpected:
+		return &exitError{code: exitCodeRain}
+	}
+	return nil
+}
+
 // checkRain determines whether rain is expected in the next `within` minutes.
-// It uses the high-resolution graph data when available (10-minute intervals
-// up to StartLowResolution, then 60-minute intervals thereafter), and falls
-// back to today's daily precipitation total when graph data is absent.
-func checkRain(plz, within int, detail *api.PLZDetail, now time.Time) rainResult {
-	result := rainResult{PLZ: plz, WithinMinutes: within}
+// It uses the graph data when available (10-minute intervals up to
+// StartLowResolution, then 60-minute intervals thereafter), and falls back to
+// today's daily precipitation total when graph data is absent. Slots count as
+// wet when their intensity reaches threshold (mm/h); for the daily fallback
+// the day's total must reach dailyThresholdMM(threshold). Amounts in the
+// message are given in the units u, and times are in now's location.
+func checkRain(plz, within int, threshold float64, detail *api.PLZDetail, now time.Time, u units.System) rainResult {
+	result := rainResult{PLZ: plz, WithinMinutes: within, ThresholdMMH: threshold}
+	end := now.Add(time.Duration(within) * time.Minute)
+	result.Explanation = []explainStep{explainWindow(now, end, threshold)}
 
+	var series api.PrecipSeries
 	if detail.Graph != nil && len(detail.Graph.Precipitation10m) > 0 {
-		maxMM, ok := graphRainInWindow(detail.Graph, now, time.Duration(within)*time.Minute)
-		if ok {
-			result.MaxRainMM = maxMM
-			result.RainExpected = maxMM > 0
+		series = detail.Graph.PrecipitationSeries().In(now.Location())
+	}
+	if len(series) > 0 {
+		result.Explanation = append(result.Explanation, explainSource(detail.Graph, series, now.Location())...)
+		inWindow := series.Window(now, end)
+		if len(inWindow) > 0 {
+			result.Source = rainSourceNowcast
+			result.Timeline = rainTimeline(inWindow)
+			result.Explanation = append(result.Explanation, explainSlots(result.Timeline, threshold)...)
+			summarizeSeries(&result, series, inWindow, u)
 			if result.RainExpected {
-				result.Message = fmt.Sprintf("Rain expected: up to %.1f mm in the next %d min", maxMM, within)
-			} else {
-				result.Message = fmt.Sprintf("No rain expected in the next %d min", within)
+				result.PrecipType = guessPrecipType(detail, *result.RainStart)
+				result.Message = spellMessage(result, now, series[len(series)-1].End, u)
 			}
+			result.Explanation = append(result.Explanation, explainVerdict(result))
 			return result
 		}
+		result.Explanation = append(result.Explanation, explainStep{
+			Step:   stepFallback,
+			Detail: fmt.Sprintf("Graph data ends at %s, before the window starts", series[len(series)-1].End.Format("15:04")),
+		})
+	} else {
+		result.Explanation = append(result.Explanation, explainStep{Step: stepFallback, Detail: "No 10-minute graph data in the response"})
 	}
 
 	// Fallback: today's daily total from the forecast.
 	if len(detail.Forecast) > 0 {
 		today := detail.Forecast[0]
+		result.Source = rainSourceDaily
 		result.MaxRainMM = today.Precipitation
-		result.RainExpected = today.Precipitation > 0
+		result.TotalMM = today.Precipitation
+		dailyMM := dailyThresholdMM(threshold)
+		result.RainExpected = today.Precipitation > 0 && today.Precipitation >= dailyMM
+		result.Explanation = append(result.Explanation, explainStep{
+			Step: stepFallback,
+			Detail: fmt.Sprintf("Daily forecast for %s: %.1f mm; wet at ≥ %.2f mm, one hour at the threshold",
+				today.DayDate, today.Precipitation, dailyMM),
+		})
 		if result.RainExpected {
-			result.Message = fmt.Sprintf("Rain possible today: %.1f mm forecast (hourly data unavailable)", today.Precipitation)
+			result.PrecipType = guessPrecipType(detail, now)
+			result.Message = fmt.Sprintf("%s possible today: %s forecast (hourly data unavailable)",
+				capitalize(precipNoun(result.PrecipType)), precipAmount(u, today.Precipitation))
 		} else {
 			result.Message = "No rain expected today (hourly data unavailable)"
 		}
+		result.Explanation = append(result.Explanation, explainVerdict(result))
 		return result
 	}
 
+	result.Source = rainSourceNone
 	result.Message = "Rain data unavailable"
+	result.Explanation = append(result.Explanation, explainVerdict(result))
 	return result
 }
 
 const (
-	hiInterval = 10 * time.Minute // high-resolution slot width
-	loInterval = 60 * time.Minute // low-resolution slot width
+	hiInterval = api.HighResolution // high-resolution slot width
+	loInterval = api.LowResolution  // low-resolution slot width
 )
 
-// graphRainInWindow returns the maximum precipitation value (mm) across all
-// graph slots that overlap [now, now+window].
-//
-// The GraphData layout is:
-//   - Slots [0 … hiCount-1]: 10-minute resolution, starting at Graph.Start.
-//   - Slots [hiCount … N]:   60-minute resolution, starting at Graph.StartLowResolution.
-//
-// Returns (0, false) when now falls entirely outside the available data.
-func graphRainInWindow(g *api.GraphData, now time.Time, window time.Duration) (maxMM float64, ok bool) {
-	if g.Start == 0 {
-		return 0, false
+// summarizeSeries fills in the rain verdict, start/stop times, peak and dry
+// time from the slots of the look-ahead window. The full series is used to
+// find when a rain spell that begins inside the window stops, even if that is
+// after the window closes. The message is only set for dry windows; see
+// spellMessage for the wet case.
+func summarizeSeries(r *rainResult, all, inWindow api.PrecipSeries, u units.System) {
+	wet := func(s api.PrecipSlot) bool {
+		return s.Amount > 0 && s.Intensity() >= r.ThresholdMMH
 	}
-	end := now.Add(window)
 
-	// High-resolution (10-min) slots.
-	hiStart := time.UnixMilli(g.Start)
-	for i, p := range g.Precipitation10m {
-		slotStart := hiStart.Add(time.Duration(i) * hiInterval)
-		if slotStart.After(end) {
-			break
+	var dry, covered time.Duration
+	for _, s := range inWindow.Resample(api.HighResolution) {
+		if s.Amount > r.MaxRainMM {
+			r.MaxRainMM = s.Amount
 		}
-		slotEnd := slotStart.Add(hiInterval)
-		if !slotEnd.After(now) {
-			continue
+	}
+	for _, s := range inWindow {
+		if !wet(s) {
+			dry += s.Duration()
 		}
-		ok = true
-		if p > maxMM {
-			maxMM = p
+		covered += s.Duration()
+	}
+	r.DryMinutes = int(dry / time.Minute)
+	r.TotalMM = inWindow.Total()
+	if covered > 0 {
+		r.WetFraction = float64(covered-dry) / float64(covered)
+	}
+
+	mmh, peak, _ := inWindow.MaxIntensity()
+	r.MaxIntensityMMH = mmh
+	r.IntensityClass = api.IntensityClass(mmh)
+	r.RainExpected = r.WetFraction > 0
+
+	if !r.RainExpected {
+		if mmh > 0 {
+			r.Message = fmt.Sprintf("Only traces of precipitation (peak %s) in the next %d min", precipRate(u, mmh, 1), r.WithinMinutes)
+		} else {
+			r.Message = fmt.Sprintf("No rain expected in the next %d min", r.WithinMinutes)
 		}
+		return
 	}
 
-	// Low-resolution (1-hour) slots.
-	if g.StartLowResolution != 0 {
-		loStart := time.UnixMilli(g.StartLowResolution)
-		for i, p := range g.Precipitation1h {
-			slotStart := loStart.Add(time.Duration(i) * loInterval)
-			if slotStart.After(end) {
-				break
-			}
-			slotEnd := slotStart.Add(loInterval)
-			if !slotEnd.After(now) {
-				continue
-			}
-			ok = true
-			if p > maxMM {
-				maxMM = p
+	peakAt := peak.Start
+	r.PeakAt = &peakAt
+
+	var start time.Time
+	for _, s := range inWindow {
+		if wet(s) {
+			start = s.Start
+			break
+		}
+	}
+	r.RainStart = &start
+	for _, s := range all {
+		if s.End.After(start) && !wet(s) {
+			stop := s.Start
+			r.RainStop = &stop
+			break
+		}
+	}
+
+}
+
+// spellMessage describes a wet window, e.g. "Rain from ~14:20 to ~15:10, peak
+// 2.4 mm/h at 14:40". dataEnd is where the graph data runs out.
+func spellMessage(r rainResult, now, dataEnd time.Time, u units.System) string {
+	noun := capitalize(precipNoun(r.PrecipType))
+	msg := noun + " now"
+	if r.RainStart.After(now) {
+		msg = fmt.Sprintf("%s from ~%s", noun, r.RainStart.Format("15:04"))
+	}
+	if r.RainStop != nil {
+		msg += fmt.Sprintf(" to ~%s", r.RainStop.Format("15:04"))
+	} else {
+		msg += fmt.Sprintf(", continuing past ~%s", dataEnd.Format("15:04"))
+	}
+	return msg + fmt.Sprintf(", peak %s at %s", precipRate(u, r.MaxIntensityMMH, 0), r.PeakAt.Format("15:04"))
+}
+
+// guessPrecipType guesses whether precipitation at time at falls as rain,
+// snow, sleet, freezing rain or hail. It combines the hourly temperature with
+// the categories of the current and today's weather icons; without any
+// evidence to the contrary it assumes rain.
+func guessPrecipType(detail *api.PLZDetail, at time.Time) string {
+	var hint string
+	categories := []string{api.IconCategory(detail.CurrentWeather.Icon)}
+	if len(detail.Forecast) > 0 {
+		categories = append(categories, api.IconCategory(detail.Forecast[0].IconDay))
+	}
+	for _, c := range categories {
+		switch c {
+		case api.CategorySnow, api.CategorySleet, api.CategoryFreezingRain, api.CategoryHail:
+			if hint == "" {
+				hint = c
 			}
 		}
 	}
 
-	return maxMM, ok
+	temp, _, hasTemp := graphTemperatureRange(detail.Graph, at, at)
+	switch {
+	case !hasTemp:
+		if hint != "" {
+			return hint
+		}
+	case temp <= 0:
+		if hint == api.CategoryFreezingRain {
+			return hint
+		}
+		return api.CategorySnow
+	case temp <= 2:
+		if hint == api.CategorySnow || hint == api.CategoryFreezingRain {
+			return hint
+		}
+		return api.CategorySleet
+	case hint == api.CategoryHail || (temp <= 4 && hint != ""):
+		return hint
+	}
+	return api.CategoryRain
+}
+
+// precipNoun returns the lower-case noun used in messages for a precipitation
+// type.
+func precipNoun(precipType string) string {
+	switch precipType {
+	case api.CategorySnow:
+		return "snow"
+	case api.CategorySleet:
+		return "sleet"
+	case api.CategoryFreezingRain:
+		return "freezing rain"
+	case api.CategoryHail:
+		return "hail"
+	default:
+		return "rain"
+	}
 }
 
+// precipEmoji returns the emoji shown next to a rain check verdict.
+func precipEmoji(precipType string) string {
+	switch precipType {
+	case api.CategorySnow, api.CategorySleet:
+		return "🌨️"
+	case api.CategoryHail:
+		return "⛈️"
+	default:
+		return "🌧️"
+	}
+}
 
-func printRainCheck(r rainResult) {
+// capitalize upper-cases the first letter of an ASCII string.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// rainTimeline converts a precipitation series into its JSON representation.
+func rainTimeline(series api.PrecipSeries) []rainSlot {
+	slots := make([]rainSlot, 0, len(series))
+	for _, s := range series {
+		res := "10m"
+		if s.Resolution == api.LowResolution {
+			res = "1h"
+		}
+		slots = append(slots, rainSlot{
+			Start:        s.Start,
+			End:          s.End,
+			Resolution:   res,
+			MM:           s.Amount,
+			IntensityMMH: s.Intensity(),
+			Class:        api.IntensityClass(s.Intensity()),
+		})
+	}
+	return slots
+}
+
+// graphRainInWindow returns the peak precipitation intensity (mm/h) across
+// the graph slots that overlap [now, now+window]. 10-minute and hourly slots
+// are compared on the same mm/h scale.
+//
+// Returns (0, false) when now falls entirely outside the available data.
+func graphRainInWindow(g *api.GraphData, now time.Time, window time.Duration) (maxMMH float64, ok bool) {
+	maxMMH, _, ok = g.PrecipitationSeries().Window(now, now.Add(window)).MaxIntensity()
+	return maxMMH, ok
+}
+
+// Status summarises the rain check for status bars, e.g. "🌧️ 14:20" when
+// rain starts at 14:20 or "☀️ dry".
+func (r rainResult) Status() out.Status {
+	s := out.Status{
+		Text:       "☀️ dry",
+		Tooltip:    r.Message,
+		Class:      out.StatusClass(r.warnLevel, r.RainExpected),
+		Percentage: int(math.Round(r.WetFraction * 100)),
+	}
+	switch {
+	case r.RainStart != nil:
+		s.Text = precipEmoji(r.PrecipType) + " " + r.RainStart.Format("15:04")
+	case r.RainExpected:
+		s.Text = precipEmoji(r.PrecipType) + " today"
+	}
+	if r.IntensityClass != "" && r.IntensityClass != api.ClassNone {
+		s.Tooltip += fmt.Sprintf("\nIntensity: %s, %.1f mm in %s", r.IntensityClass, r.TotalMM, formatMinutes(r.WithinMinutes))
+	}
+	return s
+}
+
+func printRainCheck(w io.Writer, p out.Palette, u units.System, r rainResult) {
 	icon := "☀️"
 	if r.RainExpected {
-		icon = "🌧️"
+		icon = precipEmoji(r.PrecipType)
+	}
+	out.Sep(w, 50)
+	if r.At != nil {
+		fmt.Fprintf(w, "  Rain check for PLZ %d  (%s from %s)\n", r.PLZ, formatMinutes(r.WithinMinutes), r.At.Format("Mon 15:04"))
+	} else {
+		fmt.Fprintf(w, "  Rain check for PLZ %d  (next %d min)\n", r.PLZ, r.WithinMinutes)
+	}
+	out.Sep(w, 50)
+	fmt.Fprintf(w, "  %s  %s\n", icon, p.Rain(r.Message, r.RainExpected))
+	if r.Source == rainSourceDaily {
+		fmt.Fprintln(w, "      Based on the daily forecast only; no nowcast available")
+	}
+	if r.IntensityClass != "" && r.IntensityClass != api.ClassNone {
+		fmt.Fprintf(w, "      Intensity: %s (threshold %s)\n", r.IntensityClass, precipRate(u, r.ThresholdMMH, 0))
+	}
+	if len(r.Timeline) > 0 && r.RainExpected {
+		fmt.Fprintf(w, "      %s in total, dry for %d of the next %d min\n", precipAmount(u, r.TotalMM), r.DryMinutes, r.WithinMinutes)
+	}
+	out.Sep(w, 50)
+}
+
+func printRainWindows(w io.Writer, p out.Palette, u units.System, plz int, results []rainResult) {
+	tbl := out.TextTable{
+		Indent: 2,
+		Columns: []out.Column{
+			{Header: "Window"},
+			{Header: "Total " + u.PrecipUnit(), Right: true},
+			{Header: "Peak " + u.PrecipUnit() + "/h", Right: true, Drop: 2},
+			{Header: "Wet", Right: true, Drop: 1},
+			{Header: "", Min: 8},
+		},
+	}
+	for _, r := range results {
+		verdict := "dry"
+		if r.RainStart != nil {
+			verdict = "from " + r.RainStart.Format("15:04")
+		} else if r.RainExpected {
+			verdict = "rain possible (daily)"
+		}
+		tbl.Rows = append(tbl.Rows, []string{
+			formatMinutes(r.WithinMinutes),
+			u.FormatPrecip(r.TotalMM),
+			u.FormatPrecip(r.MaxIntensityMMH),
+			fmt.Sprintf("%.0f%%", r.WetFraction*100),
+			p.Rain(verdict, r.RainExpected),
+		})
+	}
+	width := out.Width(w)
+	header, rows, used := tbl.Layout(w)
+	sep := min(max(60, used), width)
+
+	out.Sep(w, sep)
+	if at := results[0].At; at != nil {
+		fmt.Fprintf(w, "  Rain outlook for PLZ %d  (from %s)\n", plz, at.Format("Mon 15:04"))
+	} else {
+		fmt.Fprintf(w, "  Rain outlook for PLZ %d\n", plz)
+	}
+	out.Sep(w, sep)
+	fmt.Fprintln(w, header)
+	out.Sep(w, sep)
+	for _, row := range rows {
+		fmt.Fprintln(w, row)
 	}
-	out.Sep(50)
-	fmt.Printf("  Rain check for PLZ %d  (next %d min)\n", r.PLZ, r.WithinMinutes)
-	out.Sep(50)
-	fmt.Printf("  %s  %s\n", icon, r.Message)
-	out.Sep(50)
+	out.Sep(w, sep)
 }
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
 )
 
 // anchor is a fixed reference time used across all table-driven tests so
@@ -46,15 +50,15 @@ func TestGraphRainInWindow_noRain(t *testing.T) {
 }
 
 func TestGraphRainInWindow_rainInWindow(t *testing.T) {
-	// Slot at +20min (index 2) has 3.5 mm.
+	// Slot at +20min (index 2) has 3.5 mm in 10 minutes, i.e. 21 mm/h.
 	slots := []float64{0, 0, 3.5, 0, 0, 0}
 	g := makeGraph(slots, nil)
 	max, ok := graphRainInWindow(g, anchor, 30*time.Minute)
 	if !ok {
 		t.Fatal("expected ok=true, got false")
 	}
-	if max != 3.5 {
-		t.Errorf("maxMM = %.2f, want 3.5", max)
+	if max != 21 {
+		t.Errorf("maxMMH = %.2f, want 21", max)
 	}
 }
 
@@ -72,15 +76,15 @@ func TestGraphRainInWindow_rainOutsideWindow(t *testing.T) {
 }
 
 func TestGraphRainInWindow_picksMaxNotFirst(t *testing.T) {
-	// Multiple rainy slots; should return the maximum.
+	// Multiple rainy slots; should return the maximum (2.5 mm/10 min).
 	slots := []float64{0, 1.0, 2.5, 0.5}
 	g := makeGraph(slots, nil)
 	max, ok := graphRainInWindow(g, anchor, 30*time.Minute)
 	if !ok {
 		t.Fatal("expected ok=true")
 	}
-	if max != 2.5 {
-		t.Errorf("maxMM = %.2f, want 2.5", max)
+	if max != 15 {
+		t.Errorf("maxMMH = %.2f, want 15", max)
 	}
 }
 
@@ -118,6 +122,18 @@ func TestGraphRainInWindow_lowResSlot(t *testing.T) {
 	}
 }
 
+func TestGraphRainInWindow_mixedResolutionsSameUnit(t *testing.T) {
+	// 0.5 mm in 10 minutes (3 mm/h) is heavier than 2 mm in an hour (2 mm/h).
+	g := makeGraph([]float64{0.5}, []float64{2.0})
+	max, ok := graphRainInWindow(g, anchor, 70*time.Minute)
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if max != 3 {
+		t.Errorf("maxMMH = %.2f, want 3 (10-min slot converted to mm/h)", max)
+	}
+}
+
 func TestGraphRainInWindow_emptyData(t *testing.T) {
 	g := makeGraph([]float64{}, nil)
 	_, ok := graphRainInWindow(g, anchor, 30*time.Minute)
@@ -132,7 +148,7 @@ func TestCheckRain_graphDataNoRain(t *testing.T) {
 	detail := &api.PLZDetail{
 		Graph: makeGraph(make([]float64, 6), nil), // 6 dry 10-min slots
 	}
-	result := checkRain(8000, 30, detail, anchor)
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
 	if result.RainExpected {
 		t.Error("RainExpected = true, want false")
 	}
@@ -146,7 +162,7 @@ func TestCheckRain_graphDataRainExpected(t *testing.T) {
 	detail := &api.PLZDetail{
 		Graph: makeGraph(slots, nil),
 	}
-	result := checkRain(8000, 30, detail, anchor)
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
 	if !result.RainExpected {
 		t.Error("RainExpected = false, want true")
 	}
@@ -155,6 +171,40 @@ func TestCheckRain_graphDataRainExpected(t *testing.T) {
 	}
 }
 
+func TestCheckRain_lowResolutionNormalised(t *testing.T) {
+	// 1.2 mm over an hour is 0.2 mm per 10 minutes at 1.2 mm/h.
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0, 0}, []float64{1.2})}
+	result := checkRain(8000, 60, defaultRainThreshold, detail, anchor, units.Metric)
+	if math.Abs(result.MaxRainMM-0.2) > 1e-9 {
+		t.Errorf("MaxRainMM = %.3f, want 0.2", result.MaxRainMM)
+	}
+	if math.Abs(result.MaxIntensityMMH-1.2) > 1e-9 {
+		t.Errorf("MaxIntensityMMH = %.3f, want 1.2", result.MaxIntensityMMH)
+	}
+}
+
+func TestCheckRain_totalsAndWetFraction(t *testing.T) {
+	// 0.3 mm over two wet slots out of six.
+	slots := []float64{0, 0.1, 0.2, 0, 0, 0}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := checkRain(8000, 60, defaultRainThreshold, detail, anchor, units.Metric)
+	if math.Abs(result.TotalMM-0.3) > 1e-9 {
+		t.Errorf("TotalMM = %.3f, want 0.3", result.TotalMM)
+	}
+	if math.Abs(result.WetFraction-1.0/3) > 1e-9 {
+		t.Errorf("WetFraction = %.3f, want 0.333", result.WetFraction)
+	}
+}
+
+func TestCheckRain_totalProratesPartialSlots(t *testing.T) {
+	// Window 12:30–13:00 covers half of the 1.2 mm hourly slot.
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0}, []float64{1.2})}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor.Add(30*time.Minute), units.Metric)
+	if math.Abs(result.TotalMM-0.6) > 1e-9 {
+		t.Errorf("TotalMM = %.3f, want 0.6", result.TotalMM)
+	}
+}
+
 func TestCheckRain_fallbackToDailyRainy(t *testing.T) {
 	// No graph data — falls back to Forecast.
 	detail := &api.PLZDetail{
@@ -162,7 +212,7 @@ func TestCheckRain_fallbackToDailyRainy(t *testing.T) {
 			{DayDate: "2026-02-20", Precipitation: 5.5},
 		},
 	}
-	result := checkRain(8000, 30, detail, anchor)
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
 	if !result.RainExpected {
 		t.Error("RainExpected = false, want true")
 	}
@@ -177,14 +227,27 @@ func TestCheckRain_fallbackToDailyDry(t *testing.T) {
 			{DayDate: "2026-02-20", Precipitation: 0},
 		},
 	}
-	result := checkRain(8000, 30, detail, anchor)
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
 	if result.RainExpected {
 		t.Error("RainExpected = true, want false")
 	}
 }
 
+func TestCheckRain_fallbackToDailyThreshold(t *testing.T) {
+	// The daily total must hold an hour at the threshold: 0.5 mm at 0.5 mm/h.
+	for _, tc := range []struct {
+		mm   float64
+		want bool
+	}{{0.4, false}, {0.5, true}, {12, true}} {
+		detail := &api.PLZDetail{Forecast: []api.DayForecast{{DayDate: "2026-02-20", Precipitation: tc.mm}}}
+		if got := checkRain(8000, 30, 0.5, detail, anchor, units.Metric).RainExpected; got != tc.want {
+			t.Errorf("%.1f mm at 0.5 mm/h: RainExpected = %v, want %v", tc.mm, got, tc.want)
+		}
+	}
+}
+
 func TestCheckRain_noDataAtAll(t *testing.T) {
-	result := checkRain(8000, 30, &api.PLZDetail{}, anchor)
+	result := checkRain(8000, 30, defaultRainThreshold, &api.PLZDetail{}, anchor, units.Metric)
 	if result.RainExpected {
 		t.Error("RainExpected = true, want false for empty detail")
 	}
@@ -195,7 +258,7 @@ func TestCheckRain_noDataAtAll(t *testing.T) {
 
 func TestCheckRain_metadataPassedThrough(t *testing.T) {
 	detail := &api.PLZDetail{}
-	result := checkRain(3000, 60, detail, anchor)
+	result := checkRain(3000, 60, defaultRainThreshold, detail, anchor, units.Metric)
 	if result.PLZ != 3000 {
 		t.Errorf("PLZ = %d, want 3000", result.PLZ)
 	}
@@ -204,6 +267,247 @@ func TestCheckRain_metadataPassedThrough(t *testing.T) {
 	}
 }
 
+// --- rain timeline ---
+
+func TestCheckRain_timelineStartStopPeak(t *testing.T) {
+	// Dry until 12:20, rain 12:20–12:50 peaking at 12:30, dry afterwards.
+	slots := []float64{0, 0, 0.1, 0.4, 0.2, 0, 0, 0, 0}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := checkRain(8000, 60, defaultRainThreshold, detail, anchor, units.Metric)
+
+	if result.RainStart == nil || !result.RainStart.Equal(anchor.Add(20*time.Minute)) {
+		t.Errorf("RainStart = %v, want 12:20", result.RainStart)
+	}
+	if result.RainStop == nil || !result.RainStop.Equal(anchor.Add(50*time.Minute)) {
+		t.Errorf("RainStop = %v, want 12:50", result.RainStop)
+	}
+	if result.PeakAt == nil || !result.PeakAt.Equal(anchor.Add(30*time.Minute)) {
+		t.Errorf("PeakAt = %v, want 12:30", result.PeakAt)
+	}
+	// 0.4 mm in 10 minutes is 2.4 mm/h.
+	if math.Abs(result.MaxIntensityMMH-2.4) > 1e-9 {
+		t.Errorf("MaxIntensityMMH = %.2f, want 2.4", result.MaxIntensityMMH)
+	}
+	if result.DryMinutes != 30 {
+		t.Errorf("DryMinutes = %d, want 30", result.DryMinutes)
+	}
+	want := "Rain from ~12:20 to ~12:50, peak 2.4 mm/h at 12:30"
+	if result.Message != want {
+		t.Errorf("Message = %q, want %q", result.Message, want)
+	}
+	if len(result.Timeline) != 6 {
+		t.Errorf("len(Timeline) = %d, want 6 slots overlapping [12:00, 13:00)", len(result.Timeline))
+	}
+}
+
+func TestCheckRain_timelineRainNow(t *testing.T) {
+	slots := []float64{0.5, 0.5, 0, 0}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor.Add(5*time.Minute), units.Metric)
+	if !strings.HasPrefix(result.Message, "Rain now to ~12:20") {
+		t.Errorf("Message = %q, want prefix %q", result.Message, "Rain now to ~12:20")
+	}
+}
+
+func TestCheckRain_timelineStopBeyondWindow(t *testing.T) {
+	// The window closes at 12:30 but the spell continues until 13:00.
+	slots := []float64{0, 0.1, 0.1, 0.1, 0.1, 0.1, 0, 0}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+	if result.RainStop == nil || !result.RainStop.Equal(anchor.Add(time.Hour)) {
+		t.Errorf("RainStop = %v, want 13:00", result.RainStop)
+	}
+}
+
+func TestCheckRain_timelineContinuesPastData(t *testing.T) {
+	slots := []float64{0, 0.1, 0.1}
+	detail := &api.PLZDetail{Graph: makeGraph(slots, nil)}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+	if result.RainStop != nil {
+		t.Errorf("RainStop = %v, want nil when rain lasts until the end of the data", result.RainStop)
+	}
+	if !strings.Contains(result.Message, "continuing past ~12:30") {
+		t.Errorf("Message = %q, should say the rain continues past 12:30", result.Message)
+	}
+}
+
+func TestCheckRain_dryHasNoTimes(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph(make([]float64, 6), nil)}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+	if result.RainStart != nil || result.RainStop != nil || result.PeakAt != nil {
+		t.Errorf("dry result has times: start=%v stop=%v peak=%v", result.RainStart, result.RainStop, result.PeakAt)
+	}
+	if result.DryMinutes != 30 {
+		t.Errorf("DryMinutes = %d, want 30", result.DryMinutes)
+	}
+}
+
+// --- thresholds, classes and precipitation type ---
+
+func TestCheckRain_drizzleBelowThreshold(t *testing.T) {
+	// 0.01 mm in 10 minutes is 0.06 mm/h: trace, not rain.
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0.01, 0}, nil)}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+	if result.RainExpected {
+		t.Error("RainExpected = true for drizzle below the threshold, want false")
+	}
+	if result.IntensityClass != api.ClassTrace {
+		t.Errorf("IntensityClass = %q, want %q", result.IntensityClass, api.ClassTrace)
+	}
+	if !strings.Contains(result.Message, "traces") {
+		t.Errorf("Message = %q, should mention traces", result.Message)
+	}
+}
+
+func TestCheckRain_customThreshold(t *testing.T) {
+	// 0.3 mm in 10 minutes (1.8 mm/h) is below a 2.5 mm/h threshold.
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0.3, 0.3, 0.3}, nil)}
+	if r := checkRain(8000, 30, 2.5, detail, anchor, units.Metric); r.RainExpected {
+		t.Error("RainExpected = true below a 2.5 mm/h threshold, want false")
+	}
+	if r := checkRain(8000, 30, 0, detail, anchor, units.Metric); !r.RainExpected {
+		t.Error("RainExpected = false with threshold 0, want true")
+	}
+}
+
+func TestCheckRain_timelineClasses(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0.5, 2.0}, nil)}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+	want := []string{api.ClassNone, api.ClassModerate, api.ClassHeavy}
+	for i, slot := range result.Timeline {
+		if slot.Class != want[i] {
+			t.Errorf("Timeline[%d].Class = %q, want %q", i, slot.Class, want[i])
+		}
+	}
+	if result.IntensityClass != api.ClassHeavy {
+		t.Errorf("IntensityClass = %q, want %q", result.IntensityClass, api.ClassHeavy)
+	}
+}
+
+func TestCheckRain_snowMessage(t *testing.T) {
+	g := makeGraph([]float64{0, 0.5, 0.5, 0}, nil)
+	g.TemperatureMean1h = []float64{-3}
+	detail := &api.PLZDetail{Graph: g}
+	result := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric)
+	if result.PrecipType != api.CategorySnow {
+		t.Errorf("PrecipType = %q, want %q", result.PrecipType, api.CategorySnow)
+	}
+	if !strings.HasPrefix(result.Message, "Snow from ~12:10") {
+		t.Errorf("Message = %q, want prefix %q", result.Message, "Snow from ~12:10")
+	}
+}
+
+func TestGuessPrecipType(t *testing.T) {
+	withTemp := func(temp float64, currentIcon, dayIcon int) *api.PLZDetail {
+		g := makeGraph([]float64{0}, nil)
+		g.TemperatureMean1h = []float64{temp}
+		return &api.PLZDetail{
+			CurrentWeather: api.CurrentWeather{Icon: currentIcon},
+			Forecast:       []api.DayForecast{{IconDay: dayIcon}},
+			Graph:          g,
+		}
+	}
+	cases := []struct {
+		name   string
+		detail *api.PLZDetail
+		want   string
+	}{
+		{"warm rain", withTemp(12, 35, 35), api.CategoryRain},
+		{"below freezing", withTemp(-2, 35, 35), api.CategorySnow},
+		{"near freezing", withTemp(1, 5, 35), api.CategorySleet},
+		{"snow icon near freezing", withTemp(1.5, 39, 35), api.CategorySnow},
+		{"snow icon but warm", withTemp(9, 39, 39), api.CategoryRain},
+		{"daily icon hint", withTemp(3, 5, 14), api.CategorySleet},
+		{"freezing rain icon", withTemp(-1, 15, 5), api.CategoryFreezingRain},
+		{"no temperature, snow icon", &api.PLZDetail{Forecast: []api.DayForecast{{IconDay: 12}}}, api.CategorySnow},
+		{"no evidence", &api.PLZDetail{}, api.CategoryRain},
+	}
+	for _, tc := range cases {
+		if got := guessPrecipType(tc.detail, anchor); got != tc.want {
+			t.Errorf("%s: guessPrecipType() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+// --- exit status ---
+
+func TestCheckRain_source(t *testing.T) {
+	nowcast := checkRain(8000, 30, defaultRainThreshold, &api.PLZDetail{Graph: makeGraph([]float64{0, 0, 0}, nil)}, anchor, units.Metric)
+	daily := checkRain(8000, 30, defaultRainThreshold, &api.PLZDetail{Forecast: []api.DayForecast{{}}}, anchor, units.Metric)
+	none := checkRain(8000, 30, defaultRainThreshold, &api.PLZDetail{}, anchor, units.Metric)
+	for _, tc := range []struct {
+		got, want string
+	}{
+		{nowcast.Source, rainSourceNowcast},
+		{daily.Source, rainSourceDaily},
+		{none.Source, rainSourceNone},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("Source = %q, want %q", tc.got, tc.want)
+		}
+	}
+}
+
+func TestRainExitStatus(t *testing.T) {
+	on := &rootFlags{exitCode: true}
+	cases := []struct {
+		name   string
+		result rainResult
+		want   int
+	}{
+		{"dry", rainResult{Source: rainSourceNowcast}, exitCodeOK},
+		{"rain", rainResult{Source: rainSourceNowcast, RainExpected: true}, exitCodeRain},
+		{"daily dry", rainResult{Source: rainSourceDaily}, exitCodeFallback},
+		{"daily rain", rainResult{Source: rainSourceDaily, RainExpected: true}, exitCodeFallback},
+		{"no data", rainResult{Source: rainSourceNone}, exitCodeError},
+	}
+	for _, tc := range cases {
+		if got := exitCode(rainExitStatus(on, tc.result)); got != tc.want {
+			t.Errorf("%s: exit code = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+	off := &rootFlags{}
+	if err := rainExitStatus(off, rainResult{Source: rainSourceNowcast, RainExpected: true}); err != nil {
+		t.Errorf("without --exit-code: rainExitStatus() = %v, want nil", err)
+	}
+}
+
+func TestExecute_rainExitCodeOnError(t *testing.T) {
+	err := execute([]string{"rain", "--zip", "500", "--exit-code"})
+	if got := exitCode(err); got != exitCodeError {
+		t.Errorf("exit code = %d, want %d", got, exitCodeError)
+	}
+}
+
+func TestExecute_rainQuietImpliesExitCode(t *testing.T) {
+	// Missing --zip fails flag validation, which must still exit with 2.
+	err := execute([]string{"rain", "-q"})
+	if got := exitCode(err); got != exitCodeError {
+		t.Errorf("exit code = %d, want %d", got, exitCodeError)
+	}
+}
+
+func TestExecute_rainQuietExitCodeBeforeRun(t *testing.T) {
+	// Errors from --at and from the root flags, which are checked before
+	// the command runs, exit with 2 as well.
+	for _, args := range [][]string{
+		{"rain", "-q", "--zip", "8000", "--at", "garbage"},
+		{"rain", "-q", "--zip", "8000", "--tz", "Nowhere/Special"},
+		{"rain", "--zip", "8000", "--format", "bogus", "--quiet"},
+	} {
+		if got := exitCode(execute(args)); got != exitCodeError {
+			t.Errorf("%v: exit code = %d, want %d", args, got, exitCodeError)
+		}
+	}
+}
+
+func TestExecute_rainErrorWithoutExitCode(t *testing.T) {
+	err := execute([]string{"rain", "--zip", "500"})
+	if got := exitCode(err); got != 1 {
+		t.Errorf("exit code = %d, want 1", got)
+	}
+}
+
 // --- CLI validation ---
 
 func TestExecute_rainMissingZip(t *testing.T) {
@@ -227,9 +531,79 @@ func TestExecute_rainWithinTooLow(t *testing.T) {
 	}
 }
 
+func TestExecute_rainWindowsOutOfRange(t *testing.T) {
+	err := execute([]string{"rain", "--zip", "8000", "--windows", "30m,25h"})
+	if err == nil {
+		t.Error("expected error for --windows 25h, got nil")
+	}
+}
+
+func TestExecute_rainWindowsAndWithinExclusive(t *testing.T) {
+	err := execute([]string{"rain", "--zip", "8000", "--within", "60", "--windows", "1h"})
+	if err == nil {
+		t.Error("expected error when --within and --windows are combined, got nil")
+	}
+}
+
+func TestExecute_rainNegativeThreshold(t *testing.T) {
+	err := execute([]string{"rain", "--zip", "8000", "--threshold", "-1"})
+	if err == nil {
+		t.Error("expected error for --threshold -1, got nil")
+	}
+}
+
 func TestExecute_rainWithinTooHigh(t *testing.T) {
 	err := execute([]string{"rain", "--zip", "8000", "--within", "1441"})
 	if err == nil {
 		t.Error("expected error for --within 1441, got nil")
 	}
 }
+
+// --- rainResult.Status ---
+
+func TestRainResult_status(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0, 0.5, 0.5, 0, 0}, nil)}
+	r := checkRain(8000, 60, defaultRainThreshold, detail, anchor, units.Metric)
+	s := r.Status()
+	if s.Text != "🌧️ 12:20" || s.Class != out.StatusRain || s.Percentage != 33 {
+		t.Errorf("Status() = %+v, want rain from 12:20, 33%%", s)
+	}
+
+	r.warnLevel = 4
+	if s := r.Status(); s.Class != out.StatusCritical {
+		t.Errorf("Class with level 4 warning = %q, want %q", s.Class, out.StatusCritical)
+	}
+
+	dry := checkRain(8000, 20, defaultRainThreshold, detail, anchor, units.Metric).Status()
+	if dry.Text != "☀️ dry" || dry.Class != out.StatusNormal {
+		t.Errorf("dry Status() = %+v, want \"☀️ dry\", normal", dry)
+	}
+}
+
+// --- tables ---
+
+func TestRainResult_table(t *testing.T) {
+	result := checkRain(8000, 20, defaultRainThreshold, &api.PLZDetail{Graph: makeGraph([]float64{0, 0.5}, nil)}, anchor, units.Metric)
+	tbl := result.Table()
+	if got := strings.Join(tbl.Columns, ","); got != "plz,start,end,resolution,mm,intensity_mm_h,class" {
+		t.Errorf("columns = %s", got)
+	}
+	if len(tbl.Rows) != 2 {
+		t.Fatalf("rows = %q, want one per slot", tbl.Rows)
+	}
+	start := anchor.Add(10 * time.Minute).Format(time.RFC3339)
+	if row := tbl.Rows[1]; row[0] != "8000" || row[1] != start || row[4] != "0.5" || row[5] != "3" {
+		t.Errorf("row = %q", row)
+	}
+}
+
+func TestRainWindows_table(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0, 0, 1.2}, nil)}
+	tbl := rainWindows{
+		checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Metric),
+		checkRain(8000, 40, defaultRainThreshold, detail, anchor, units.Metric),
+	}.Table()
+	if len(tbl.Rows) != 2 || tbl.Rows[0][1] != "30" || tbl.Rows[0][2] != "false" || tbl.Rows[1][2] != "true" {
+		t.Errorf("rows = %q", tbl.Rows)
+	}
+}
@@ -1,20 +1,81 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/a-fgx/meteoswiss-cli/internal/config"
 	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
 )
 
 var version = "0.1.0"
 
 type rootFlags struct {
+	// format is the output format selected with --format; asJSON is the
+	// --json alias and is also set for the JSON-based formats so that errors
+	// are reported as JSON. raw is set by --json=raw and drops the derived
+	// fields from structured output.
+	format string
 	asJSON bool
+	raw    bool
+
+	// template is the Go template given with --template, or read from
+	// --template-file; it replaces the selected format when set.
+	template     string
+	templateFile string
+
+	// fields and query narrow the output to some fields of each record or
+	// to the result of a JSONPath expression.
+	fields []string
+	query  string
+
+	// color is the --color mode; palette colours text output accordingly.
+	color   string
+	palette out.Palette
+
+	// units are the display units from --units and its per-quantity
+	// overrides; unitsSet is true when any of them was given, which also
+	// converts structured output.
+	unitSystem string
+	tempUnit   string
+	precipUnit string
+	windUnit   string
+	units      units.System
+	unitsSet   bool
+
+	// ascii and screenReader select the text mode of human-readable output.
+	ascii        bool
+	screenReader bool
+
+	// tz is the --tz time zone name and loc the zone it resolves to. Times
+	// are shown in loc, and --at and --start values without an offset are
+	// taken in it.
+	tz  string
+	loc *time.Location
+
+	// envelope wraps structured output in a versioned envelope; fetches
+	// records the data behind it.
+	envelope bool
+	fetches  []fetchRecord
+
+	// exitCode is set by commands that report their verdict through the
+	// process exit status; errors then exit with exitCodeError.
+	exitCode bool
 }
 
 func execute(args []string) error {
+	return run(args, os.Stdout, os.Stderr)
+}
+
+// run executes the command line args, writing results to stdout and errors
+// to stderr.
+func run(args []string, stdout, stderr io.Writer) error {
 	var flags rootFlags
 
 	rootCmd := &cobra.Command{
@@ -26,25 +87,258 @@ and active weather warnings from the MeteoSwiss app backend.`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		Version:       version,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.resolveFormat(cmd); err != nil {
+				return err
+			}
+			if err := flags.resolveUnits(cmd); err != nil {
+				return err
+			}
+			if err := flags.resolveTimeZone(); err != nil {
+				return err
+			}
+			return flags.resolveColor(cmd)
+		},
 	}
 	rootCmd.SetVersionTemplate("meteocli {{.Version}}\n")
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(stderr)
 
-	rootCmd.PersistentFlags().BoolVar(&flags.asJSON, "json", false, "output JSON instead of human-readable text")
+	rootCmd.PersistentFlags().StringVar(&flags.format, "format", out.FormatText, "output format: "+strings.Join(out.Formats, ", "))
+	rootCmd.PersistentFlags().Var(jsonValue{&flags.asJSON, &flags.raw}, "json", "output JSON instead of human-readable text (same as --format json); `mode` is enriched (default) or raw for the API fields only")
+	rootCmd.PersistentFlags().Lookup("json").NoOptDefVal = jsonEnriched
+	rootCmd.PersistentFlags().StringVar(&flags.color, "color", out.ColorAuto, "colour text output: auto (when writing to a terminal and NO_COLOR is unset), always or never")
+	rootCmd.PersistentFlags().StringVar(&flags.unitSystem, "units", units.SystemMetric, "unit system: metric, imperial or custom (metric changed by the unit flags below)")
+	rootCmd.PersistentFlags().StringVar(&flags.tempUnit, "temp-unit", "", "temperature unit: C, F or K")
+	rootCmd.PersistentFlags().StringVar(&flags.precipUnit, "precip-unit", "", "precipitation unit: mm or in")
+	rootCmd.PersistentFlags().StringVar(&flags.windUnit, "wind-unit", "", "wind speed unit: km/h, m/s, kn, mph or bft (Beaufort)")
+	rootCmd.PersistentFlags().StringVar(&flags.tz, "tz", zurich.String(), "time zone for showing and reading times, e.g. UTC or America/New_York")
+	rootCmd.PersistentFlags().BoolVar(&flags.ascii, "ascii", false, "only use ASCII in text output: text labels instead of emoji and plain separators")
+	rootCmd.PersistentFlags().BoolVar(&flags.ascii, "plain", false, "same as --ascii")
+	rootCmd.PersistentFlags().BoolVar(&flags.screenReader, "screen-reader", false, "write text output as ASCII sentences, without tables, separators or colour")
+	rootCmd.PersistentFlags().StringSliceVar(&flags.fields, "fields", nil, "only output these fields of each record, e.g. dayDate,temperatureMax (dots select nested fields)")
+	rootCmd.PersistentFlags().StringVar(&flags.query, "query", "", `only output the result of a JSONPath expression, e.g. '$[?(@.warnLevel >= 3)].headline'`)
+	rootCmd.PersistentFlags().BoolVar(&flags.envelope, "envelope", false, "wrap JSON, YAML or NDJSON output in a versioned envelope with metadata")
+	rootCmd.PersistentFlags().StringVar(&flags.template, "template", "", `render the result with a Go template, e.g. '{{.Temperature | printf "%.0f"}}°'`)
+	rootCmd.PersistentFlags().StringVar(&flags.templateFile, "template-file", "", "render the result with a Go template read from a file")
 
-	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newVersionCmd(&flags))
 	rootCmd.AddCommand(newWeatherCmd(&flags))
 	rootCmd.AddCommand(newForecastCmd(&flags))
 	rootCmd.AddCommand(newWarningsCmd(&flags))
 	rootCmd.AddCommand(newRainCmd(&flags))
+	rootCmd.AddCommand(newDryCmd(&flags))
+	rootCmd.AddCommand(newTrackCmd(&flags))
+	rootCmd.AddCommand(newPromptCmd(&flags))
+	rootCmd.AddCommand(newSchemaCmd(&flags))
 
 	rootCmd.SetArgs(args)
 	if err := rootCmd.Execute(); err != nil {
-		_ = out.WriteError(os.Stderr, flags.asJSON, err)
+		var status *exitError
+		if errors.As(err, &status) && status.err == nil {
+			return err
+		}
+		// Flag errors can occur before resolveFormat has run.
+		asJSON := flags.asJSON || flags.format == out.FormatJSON || flags.format == out.FormatNDJSON
+		_ = out.WriteError(stderr, asJSON, err)
+		if flags.exitCode && status == nil {
+			return &exitError{code: exitCodeError, err: err}
+		}
 		return err
 	}
 	return nil
 }
 
+// noArgs rejects positional arguments, for the commands that take none. A
+// stray "raw" most likely comes from "--json raw", which must be written
+// --json=raw because a bare --json selects enriched output.
+func noArgs(cmd *cobra.Command, args []string) error {
+	switch {
+	case len(args) == 0:
+		return nil
+	case args[0] == jsonRaw:
+		return fmt.Errorf("unexpected argument %q; use --json=raw for raw JSON", args[0])
+	}
+	return fmt.Errorf("%s takes no arguments, got %q", cmd.CommandPath(), args[0])
+}
+
+// resolveFormat reconciles --json, --template and --template-file with
+// --format and checks that the format is known.
+func (f *rootFlags) resolveFormat(cmd *cobra.Command) error {
+	if f.templateFile != "" {
+		if f.template != "" {
+			return fmt.Errorf("--template and --template-file cannot be combined")
+		}
+		data, err := os.ReadFile(f.templateFile)
+		if err != nil {
+			return fmt.Errorf("--template-file: %w", err)
+		}
+		f.template = string(data)
+	}
+	if f.template != "" {
+		if f.asJSON || cmd.Flags().Changed("format") {
+			return fmt.Errorf("--template cannot be combined with --json or --format")
+		}
+		if f.narrowed() {
+			return fmt.Errorf("--template cannot be combined with --fields or --query")
+		}
+		if _, err := out.NewTemplateRenderer(f.template, templateFuncs(f.loc)); err != nil {
+			return fmt.Errorf("--template: %w", err)
+		}
+		return nil
+	}
+	if f.asJSON {
+		if cmd.Flags().Changed("format") && f.format != out.FormatJSON {
+			return fmt.Errorf("--json conflicts with --format %s", f.format)
+		}
+		f.format = out.FormatJSON
+	}
+	if _, err := out.NewRenderer(f.format, nil); err != nil {
+		return err
+	}
+	if f.query != "" {
+		if _, err := out.Query(nil, f.query); err != nil {
+			return err
+		}
+	}
+	if f.narrowed() && out.IsStatusFormat(f.format) {
+		return fmt.Errorf("--fields and --query cannot be combined with --format %s", f.format)
+	}
+	if f.envelope && cmd.Name() != "schema" {
+		switch f.format {
+		case out.FormatJSON, out.FormatYAML, out.FormatNDJSON:
+		default:
+			return fmt.Errorf("--envelope requires --json or --format json, yaml or ndjson")
+		}
+	}
+	f.asJSON = f.format == out.FormatJSON || f.format == out.FormatNDJSON
+	return nil
+}
+
+// resolveTimeZone loads the --tz time zone.
+func (f *rootFlags) resolveTimeZone() error {
+	// LoadLocation takes "" as UTC, which is rather a typo here.
+	loc, err := time.LoadLocation(f.tz)
+	if err != nil || strings.TrimSpace(f.tz) == "" {
+		return fmt.Errorf("invalid --tz %q: want a time zone name such as Europe/Zurich or UTC", f.tz)
+	}
+	f.loc = loc
+	return nil
+}
+
+// now returns the current time in the --tz zone.
+func (f *rootFlags) now() time.Time {
+	return time.Now().In(f.loc)
+}
+
+// resolveColor sets up the palette for text output from --color, the
+// environment and the theme in the configuration file.
+func (f *rootFlags) resolveColor(cmd *cobra.Command) error {
+	enabled, err := out.ColorEnabled(f.color, cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	if !enabled || f.format != out.FormatText || f.template != "" || f.screenReader {
+		return nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	theme, err := out.LookupTheme(cfg.Theme)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	f.palette = out.NewPalette(theme, true)
+	return nil
+}
+
+// render writes v to w in the selected output format, or through the
+// user's template. printText produces the human-readable form used by
+// --format text. With --units or a unit flag, v is first converted to the
+// selected units.
+func (f *rootFlags) render(w io.Writer, v any, printText func(w io.Writer)) error {
+	var r out.Renderer
+	var err error
+	if uc, ok := v.(unitConverter); ok && f.unitsSet && !f.raw {
+		v = uc.inUnits(f.units)
+	}
+	if f.template != "" {
+		r, err = out.NewTemplateRenderer(f.template, templateFuncs(f.loc))
+	} else {
+		if rv, ok := v.(rawViewer); ok && f.raw {
+			v = rv.rawView()
+		}
+		var text out.Renderer = out.RenderFunc(func(w io.Writer, _ any) error {
+			printText(w)
+			return nil
+		})
+		if f.narrowed() {
+			if v, err = f.narrow(v); err != nil {
+				return err
+			}
+			text = out.RenderFunc(out.RenderPlain)
+		}
+		text = f.inTextMode(text)
+		if f.envelope {
+			v = f.wrap(v, f.now().Truncate(time.Second))
+		}
+		r, err = out.NewRenderer(f.format, text)
+	}
+	if err != nil {
+		return err
+	}
+	return r.Render(w, v)
+}
+
+// textMode returns the text mode selected with --ascii or --screen-reader.
+func (f *rootFlags) textMode() out.TextMode {
+	switch {
+	case f.screenReader:
+		return out.TextScreenReader
+	case f.ascii:
+		return out.TextASCII
+	}
+	return out.TextRich
+}
+
+// inTextMode wraps the text renderer r so that it writes in the selected
+// text mode.
+func (f *rootFlags) inTextMode(r out.Renderer) out.Renderer {
+	mode := f.textMode()
+	if mode == out.TextRich {
+		return r
+	}
+	return out.RenderFunc(func(w io.Writer, v any) error {
+		tw := out.NewTextWriter(w, mode)
+		if err := r.Render(tw, v); err != nil {
+			return err
+		}
+		return tw.Flush()
+	})
+}
+
+// narrowed reports whether --fields or --query is set.
+func (f *rootFlags) narrowed() bool {
+	return len(f.fields) > 0 || f.query != ""
+}
+
+// narrow applies --query and then --fields to v.
+func (f *rootFlags) narrow(v any) (any, error) {
+	var err error
+	if f.query != "" {
+		if v, err = out.Query(v, f.query); err != nil {
+			return nil, err
+		}
+	}
+	if len(f.fields) > 0 {
+		if v, err = out.SelectFields(v, f.fields); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
 // requirePLZ validates that a postal code looks like a valid Swiss PLZ.
 func requirePLZ(plz int) error {
 	if plz < 1000 || plz > 9999 {
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/cache"
 )
 
 // --- requirePLZ ---
@@ -119,3 +125,190 @@ func TestExecute_help(t *testing.T) {
 		t.Errorf("execute(--help) unexpected error: %v", err)
 	}
 }
+
+// --- run: output formats ---
+
+func TestRun_versionFormats(t *testing.T) {
+	cases := map[string]string{
+		"text":     "meteocli " + version + "\n",
+		"json":     "{\n  \"version\": \"" + version + "\"\n}\n",
+		"yaml":     "version: \"" + version + "\"\n",
+		"csv":      "version\n" + version + "\n",
+		"ndjson":   `{"version":"` + version + `"}` + "\n",
+		"markdown": "| version |\n| --- |\n| " + version + " |\n",
+	}
+	for format, want := range cases {
+		var stdout, stderr bytes.Buffer
+		if err := run([]string{"version", "--format", format}, &stdout, &stderr); err != nil {
+			t.Errorf("--format %s: unexpected error: %v", format, err)
+			continue
+		}
+		if stdout.String() != want {
+			t.Errorf("--format %s: output = %q, want %q", format, stdout.String(), want)
+		}
+	}
+}
+
+func TestRun_jsonIsFormatAlias(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := run([]string{"version", "--json"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), `"version":`) {
+		t.Errorf("--json output = %q, want JSON", stdout.String())
+	}
+}
+
+func TestRun_jsonRawNeedsEquals(t *testing.T) {
+	// "--json raw" is --json followed by a stray argument, not raw output.
+	for _, args := range [][]string{
+		{"version", "--json", "raw"},
+		{"weather", "--zip", "8000", "--json", "raw"},
+		{"rain", "--zip", "8000", "--json", "raw"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if err := run(args, &stdout, &stderr); err == nil || !strings.Contains(err.Error(), "--json=raw") {
+			t.Errorf("%v: err = %v, want a hint at --json=raw", args, err)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("%v: stdout = %q, want empty", args, stdout.String())
+		}
+	}
+	if err := run([]string{"forecast", "--zip", "8000", "extra"}, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+		t.Error("expected error for a positional argument, got nil")
+	}
+}
+
+func TestRun_jsonConflictsWithFormat(t *testing.T) {
+	if err := run([]string{"version", "--json", "--format", "yaml"}, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+		t.Error("expected error for --json with --format yaml, got nil")
+	}
+}
+
+func TestRun_unknownFormat(t *testing.T) {
+	var stderr bytes.Buffer
+	if err := run([]string{"version", "--format", "xml"}, &bytes.Buffer{}, &stderr); err == nil {
+		t.Error("expected error for --format xml, got nil")
+	}
+	if !strings.Contains(stderr.String(), "xml") {
+		t.Errorf("stderr = %q, want the unknown format named", stderr.String())
+	}
+}
+
+func TestRun_errorsGoToStderr(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	_ = run([]string{"weather", "--zip", "500", "--format", "json"}, &stdout, &stderr)
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), `"error"`) {
+		t.Errorf("stderr = %q, want a JSON error", stderr.String())
+	}
+}
+
+// --- run: --fields / --query ---
+
+func TestRun_fields(t *testing.T) {
+	stubRefresh(t)
+	_ = cache.Write(detailCacheKey(8000), &api.PLZDetail{CurrentWeather: api.CurrentWeather{Icon: 3, Temperature: 12.5}})
+
+	cases := map[string]string{
+		"csv":  "temperature,icon\n12.5,3\n",
+		"json": "{\n  \"temperature\": 12.5,\n  \"icon\": 3\n}\n",
+		"text": "temperature:  12.5\nicon:         3\n",
+	}
+	for format, want := range cases {
+		var stdout bytes.Buffer
+		args := []string{"prompt", "--loc", "home", "--fields", "temperature,icon", "--format", format}
+		if err := run(args, &stdout, &bytes.Buffer{}); err != nil {
+			t.Fatalf("--format %s: unexpected error: %v", format, err)
+		}
+		if stdout.String() != want {
+			t.Errorf("--format %s output = %q, want %q", format, stdout.String(), want)
+		}
+	}
+}
+
+func TestRun_query(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := run([]string{"version", "--query", "$.version"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stdout.String() != version+"\n" {
+		t.Errorf("output = %q, want %q", stdout.String(), version+"\n")
+	}
+}
+
+func TestRun_narrowingConflicts(t *testing.T) {
+	for _, args := range [][]string{
+		{"version", "--query", "$["},
+		{"version", "--fields", "version", "--template", "{{.}}"},
+		{"version", "--query", "$.version", "--format", "waybar"},
+		{"version", "--fields", "versoin"},
+	} {
+		if err := run(args, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+			t.Errorf("%v: expected error, got nil", args)
+		}
+	}
+}
+
+// --- run: --color ---
+
+func TestRun_color(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, mode := range []string{"auto", "always", "never"} {
+		if err := run([]string{"version", "--color", mode}, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Errorf("--color %s: unexpected error: %v", mode, err)
+		}
+	}
+	if err := run([]string{"version", "--color", "sometimes"}, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+		t.Error("--color sometimes: expected error, got nil")
+	}
+}
+
+func TestRun_colorUnknownTheme(t *testing.T) {
+	cfg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", cfg)
+	_ = os.MkdirAll(filepath.Join(cfg, "meteocli"), 0o755)
+	_ = os.WriteFile(filepath.Join(cfg, "meteocli", "config.json"), []byte(`{"theme": "neon"}`), 0o644)
+
+	err := run([]string{"version", "--color", "always"}, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "neon") {
+		t.Errorf("error = %v, want the unknown theme named", err)
+	}
+}
+
+// --- run: --tz ---
+
+func TestRun_tz(t *testing.T) {
+	for _, tz := range []string{"UTC", "America/New_York", "Europe/Zurich"} {
+		if err := run([]string{"version", "--tz", tz}, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Errorf("--tz %s: unexpected error: %v", tz, err)
+		}
+	}
+	for _, tz := range []string{"Mars/Olympus_Mons", ""} {
+		err := run([]string{"version", "--tz", tz}, &bytes.Buffer{}, &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "--tz") {
+			t.Errorf("--tz %q: error = %v, want an invalid --tz error", tz, err)
+		}
+	}
+}
+
+// --- run: --ascii / --screen-reader ---
+
+func TestRun_textModes(t *testing.T) {
+	cases := map[string]string{
+		"--ascii":         "meteocli " + version + "\n",
+		"--plain":         "meteocli " + version + "\n",
+		"--screen-reader": "meteocli " + version + ".\n",
+	}
+	for flag, want := range cases {
+		var stdout bytes.Buffer
+		if err := run([]string{"version", flag}, &stdout, &bytes.Buffer{}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", flag, err)
+		}
+		if stdout.String() != want {
+			t.Errorf("%s output = %q, want %q", flag, stdout.String(), want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/schema"
+)
+
+// outputSchemas returns the schema of each command's structured output.
+func outputSchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"weather":  schema.For(weatherView{}),
+		"forecast": schema.For(dayViews{}),
+		"warnings": schema.For(warningViews{}),
+		"rain": {OneOf: []*schema.Schema{
+			schema.For(rainResult{}),
+			schema.For([]rainResult{}), // with --windows
+		}},
+		"dry":     schema.For(dryResult{}),
+		"track":   schema.For(trackResult{}),
+		"prompt":  schema.For(promptView{}),
+		"version": schema.For(versionInfo{}),
+	}
+}
+
+func newSchemaCmd(flags *rootFlags) *cobra.Command {
+	schemas := outputSchemas()
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &cobra.Command{
+		Use:   "schema <command>",
+		Short: "Print the JSON Schema of a command's JSON output",
+		Long: `Print the JSON Schema (draft 2020-12) of a command's JSON output. With
+--envelope the schema describes the enveloped output, with the command's
+output under "data".
+
+Commands: ` + strings.Join(names, ", "),
+		Example: `  # Schema of "meteocli forecast --json"
+  meteocli schema forecast
+
+  # Schema of "meteocli rain --json --envelope"
+  meteocli schema rain --envelope`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: names,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, ok := schemas[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown command %q: want one of %s", args[0], strings.Join(names, ", "))
+			}
+			doc := *s
+			title := fmt.Sprintf("meteocli %s output", args[0])
+			if flags.envelope {
+				doc = *schema.For(envelope{})
+				for i, p := range doc.Properties {
+					if p.Name == "data" {
+						doc.Properties[i].Schema = s
+					}
+				}
+				title = fmt.Sprintf("meteocli %s output with --envelope", args[0])
+			}
+			doc.Schema = schema.Draft
+			doc.Title = title
+			doc.Description = "Schema version " + schemaVersion
+			return out.PrintJSON(cmd.OutOrStdout(), doc)
+		},
+	}
+}
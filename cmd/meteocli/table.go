@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strconv"
+	"time"
+)
+
+// Cell formatting for the tables of the csv, tsv and markdown formats. Values
+// are written as in the JSON output so that both parse the same way.
+
+func numberCell(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func intCell(i int) string {
+	return strconv.Itoa(i)
+}
+
+func boolCell(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+// timeCell formats t in RFC 3339; a nil or zero time is an empty cell.
+func timeCell(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// templateFuncs returns the helpers available to --template and
+// --template-file, in addition to the text/template builtins such as printf.
+// date shows times in loc.
+func templateFuncs(loc *time.Location) template.FuncMap {
+	return template.FuncMap{
+		// Weather icons.
+		"emoji":    api.IconEmoji,
+		"icon":     api.IconDescription,
+		"category": api.IconCategory,
+
+		// Warnings.
+		"warnType":  warnTypeLabel,
+		"warnLevel": warnLevelLabel,
+
+		// Unit conversion from the units the API reports.
+		"fahrenheit": units.Imperial.Temp,
+		"inches":     units.Imperial.Precip,
+		"mph":        units.Imperial.WindSpeed,
+		"ms":         units.System{Temperature: units.Celsius, Precipitation: units.Millimetres, Wind: units.MS}.WindSpeed,
+
+		"date": func(layout string, v any) (string, error) { return templateDate(layout, v, loc) },
+		"join": strings.Join,
+	}
+}
+
+// templateDate formats a time in loc with a Go layout, e.g.
+// {{.Time | date "15:04"}}. It accepts time.Time, *time.Time, Unix
+// milliseconds as used by the API, RFC 3339 strings and dates as
+// YYYY-MM-DD or YYYYMMDD, which stand for midnight.
+func templateDate(layout string, v any, loc *time.Location) (string, error) {
+	var t time.Time
+	switch v := v.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		t = *v
+	case int64:
+		t = time.UnixMilli(v)
+	case int:
+		t = time.UnixMilli(int64(v))
+	case string:
+		d, err := api.ParseDate(v)
+		if err == nil {
+			return d.In(loc).Format(layout), nil
+		}
+		if t, err = time.Parse(time.RFC3339, v); err != nil {
+			return "", fmt.Errorf("date: cannot parse %q", v)
+		}
+	default:
+		return "", fmt.Errorf("date: unsupported value of type %T", v)
+	}
+	return t.In(loc).Format(layout), nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// --- templateDate ---
+
+func TestTemplateDate(t *testing.T) {
+	at := time.Date(2026, 10, 18, 7, 30, 0, 0, zurich)
+	cases := []struct {
+		in   any
+		want string
+	}{
+		{at, "Sun 07:30"},
+		{&at, "Sun 07:30"},
+		{at.UnixMilli(), "Sun 07:30"},
+		{at.UTC(), "Sun 07:30"},
+		{"2026-10-18T05:30:00Z", "Sun 07:30"},
+		{"2026-10-18", "Sun 00:00"},
+		{"20261018", "Sun 00:00"},
+		{(*time.Time)(nil), ""},
+	}
+	for _, tc := range cases {
+		got, err := templateDate("Mon 15:04", tc.in, zurich)
+		if err != nil || got != tc.want {
+			t.Errorf("templateDate(%v) = %q, %v; want %q", tc.in, got, err, tc.want)
+		}
+	}
+	if _, err := templateDate("15:04", 1.5, zurich); err == nil {
+		t.Error("expected error for float value, got nil")
+	}
+}
+
+// --- templateFuncs ---
+
+func TestTemplateFuncs(t *testing.T) {
+	const tmpl = `{{.CurrentWeather.Icon | emoji}} {{.CurrentWeather.Icon | icon}} ` +
+		`{{.CurrentWeather.Temperature | fahrenheit | printf "%.0f"}}°F ` +
+		`{{range .Warnings}}{{warnType .WarnType}}/{{warnLevel .WarnLevel}} {{join .Regions ","}}{{end}}`
+	r, err := out.NewTemplateRenderer(tmpl, templateFuncs(zurich))
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error: %v", err)
+	}
+	detail := &api.PLZDetail{
+		CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: 20},
+		Warnings:       []api.Warning{{WarnType: 1, WarnLevel: 3, Regions: []string{"BE", "FR"}}},
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, detail); err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	want := api.IconEmoji(1) + " " + api.IconDescription(1) + " 68°F " +
+		warnTypeLabel(1) + "/" + warnLevelLabel(3) + " BE,FR\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateFuncs_units(t *testing.T) {
+	r, err := out.NewTemplateRenderer(`{{fahrenheit 20.0}} {{inches 25.4}} {{mph 1.609344}} {{ms 36.0}}`, templateFuncs(zurich))
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, nil); err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if got, want := buf.String(), "68 1 1 10\n"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+// --- run: --template ---
+
+func TestRun_template(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := run([]string{"version", "--template", "v{{.Version}}"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stdout.String(); got != "v"+version+"\n" {
+		t.Errorf("output = %q, want %q", got, "v"+version+"\n")
+	}
+}
+
+func TestRun_templateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "line.tmpl")
+	if err := os.WriteFile(path, []byte("meteocli {{.Version}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	if err := run([]string{"version", "--template-file", path}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stdout.String(); got != "meteocli "+version+"\n" {
+		t.Errorf("output = %q, want %q", got, "meteocli "+version+"\n")
+	}
+}
+
+func TestRun_templateErrors(t *testing.T) {
+	cases := [][]string{
+		{"version", "--template", "{{.Version"},
+		{"version", "--template", "x", "--json"},
+		{"version", "--template", "x", "--format", "yaml"},
+		{"version", "--template", "x", "--template-file", "y"},
+		{"version", "--template-file", filepath.Join(t.TempDir(), "missing.tmpl")},
+	}
+	for _, args := range cases {
+		if err := run(args, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+			t.Errorf("run(%q): expected error, got nil", args)
+		}
+	}
+}
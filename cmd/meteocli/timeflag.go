@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Europe/Zurich must resolve even without system zoneinfo
+)
+
+// zurich is the time zone of the MeteoSwiss forecasts: their day dates are
+// Swiss dates. It is also the default of --tz.
+var zurich = mustLoadLocation("Europe/Zurich")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// parseAt parses a reference time for window-based commands. It accepts
+//
+//   - "" or "now"
+//   - a relative offset such as "+3h" or "+90m"
+//   - a clock time "HH:MM", optionally prefixed with "today" or "tomorrow"
+//   - a date and time "YYYY-MM-DD HH:MM" or "YYYY-MM-DDTHH:MM"
+//   - an RFC 3339 timestamp
+//
+// Times without an offset are taken in loc, the --tz zone, and all times are
+// returned in loc. A bare clock time means today, even if it has already
+// passed.
+func parseAt(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "" || strings.EqualFold(s, "now"):
+		return now, nil
+	case strings.HasPrefix(s, "+"):
+		d, err := time.ParseDuration(s[1:])
+		if err != nil || d < 0 {
+			return time.Time{}, fmt.Errorf("invalid time %q: want an offset like +3h or +90m", s)
+		}
+		return now.Add(d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	day := now.In(loc)
+	clock := s
+	if word, rest, ok := strings.Cut(s, " "); ok {
+		switch strings.ToLower(word) {
+		case "today":
+		case "tomorrow":
+			day = day.AddDate(0, 0, 1)
+		default:
+			return time.Time{}, invalidAt(s)
+		}
+		clock = strings.TrimSpace(rest)
+	}
+	c, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, invalidAt(s)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), c.Hour(), c.Minute(), 0, 0, loc), nil
+}
+
+// shiftedAt returns the reference time for a result when --at was given, in
+// loc, and nil when the window simply starts now.
+func shiftedAt(flag string, at time.Time, loc *time.Location) *time.Time {
+	if strings.TrimSpace(flag) == "" || strings.EqualFold(strings.TrimSpace(flag), "now") {
+		return nil
+	}
+	t := at.In(loc)
+	return &t
+}
+
+func invalidAt(s string) error {
+	return fmt.Errorf(`invalid time %q: want "HH:MM", "tomorrow HH:MM", "YYYY-MM-DD HH:MM" or "+3h"`, s)
+}
+
+// minutesValue is a pflag.Value for look-ahead windows. It accepts a Go
+// duration ("90m", "2h", "1h30m") or, for compatibility, a bare number of
+// minutes.
+type minutesValue int
+
+func newMinutesValue(def int, p *int) *minutesValue {
+	*p = def
+	return (*minutesValue)(p)
+}
+
+func (m *minutesValue) Set(s string) error {
+	if n, err := strconv.Atoi(s); err == nil {
+		*m = minutesValue(n)
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("want minutes or a duration like 90m or 2h")
+	}
+	if d%time.Minute != 0 {
+		return fmt.Errorf("must be a whole number of minutes")
+	}
+	*m = minutesValue(d / time.Minute)
+	return nil
+}
+
+func (m *minutesValue) String() string { return formatMinutes(int(*m)) }
+
+func (m *minutesValue) Type() string { return "duration" }
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// --- parseAt ---
+
+func TestParseAt(t *testing.T) {
+	// 09:15 in Zurich on a Saturday in October (CEST, UTC+2).
+	now := time.Date(2026, 10, 17, 7, 15, 0, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{"", now},
+		{"now", now},
+		{"+3h", now.Add(3 * time.Hour)},
+		{"+90m", now.Add(90 * time.Minute)},
+		{"17:30", time.Date(2026, 10, 17, 15, 30, 0, 0, time.UTC)},
+		{"08:00", time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC)},
+		{"today 18:00", time.Date(2026, 10, 17, 16, 0, 0, 0, time.UTC)},
+		{"tomorrow 07:30", time.Date(2026, 10, 18, 5, 30, 0, 0, time.UTC)},
+		{"2026-10-17T18:00", time.Date(2026, 10, 17, 16, 0, 0, 0, time.UTC)},
+		{"2026-10-18 08:00", time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)},
+		{"2026-12-01 08:00", time.Date(2026, 12, 1, 7, 0, 0, 0, time.UTC)}, // CET
+		{"2026-10-17T18:00:00Z", time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		got, err := parseAt(tc.in, now, zurich)
+		if err != nil {
+			t.Errorf("parseAt(%q) error: %v", tc.in, err)
+			continue
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("parseAt(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseAt_tomorrowFromLateEvening(t *testing.T) {
+	// 23:30 in Zurich is still the previous day in UTC.
+	now := time.Date(2026, 10, 17, 21, 30, 0, 0, time.UTC)
+	got, err := parseAt("tomorrow 07:30", now, zurich)
+	want := time.Date(2026, 10, 18, 5, 30, 0, 0, time.UTC)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("parseAt(\"tomorrow 07:30\") = %v, %v; want %v", got, err, want)
+	}
+}
+
+func TestParseAt_invalid(t *testing.T) {
+	for _, in := range []string{"tomorrow-ish", "+soon", "+-1h", "yesterday 08:00", "25:00", "2026-13-01 08:00"} {
+		if _, err := parseAt(in, anchor, zurich); err == nil {
+			t.Errorf("parseAt(%q): expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseAt_location(t *testing.T) {
+	// With --tz UTC, the same clock time is two hours later than in Zurich.
+	now := time.Date(2026, 10, 17, 7, 15, 0, 0, time.UTC)
+	got, err := parseAt("17:30", now, time.UTC)
+	want := time.Date(2026, 10, 17, 17, 30, 0, 0, time.UTC)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("parseAt(\"17:30\") in UTC = %v, %v; want %v", got, err, want)
+	}
+}
+
+func TestParseAt_offsetInLocation(t *testing.T) {
+	// An RFC 3339 time keeps its instant but is shown in the --tz zone.
+	got, err := parseAt("2026-10-18T10:00:00Z", anchor, zurich)
+	want := time.Date(2026, 10, 18, 12, 0, 0, 0, zurich)
+	if err != nil || !got.Equal(want) || got.Location() != zurich {
+		t.Errorf("parseAt(\"2026-10-18T10:00:00Z\") = %v, %v; want %v", got, err, want)
+	}
+}
+
+func TestShiftedAt(t *testing.T) {
+	if shiftedAt("", anchor, zurich) != nil || shiftedAt("now", anchor, zurich) != nil {
+		t.Error("shiftedAt() without --at should be nil")
+	}
+	got := shiftedAt("+1h", anchor, zurich)
+	if got == nil || got.Location() != zurich || !got.Equal(anchor) {
+		t.Errorf("shiftedAt(\"+1h\") = %v, want %v in Europe/Zurich", got, anchor)
+	}
+}
+
+// --- minutesValue ---
+
+func TestMinutesValue(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int
+	}{
+		{"45", 45},
+		{"90m", 90},
+		{"2h", 120},
+		{"1h30m", 90},
+	}
+	for _, tc := range cases {
+		var n int
+		v := newMinutesValue(30, &n)
+		if err := v.Set(tc.in); err != nil || n != tc.want {
+			t.Errorf("Set(%q) = %d, %v; want %d", tc.in, n, err, tc.want)
+		}
+	}
+	var n int
+	for _, in := range []string{"soon", "90s"} {
+		if err := newMinutesValue(30, &n).Set(in); err == nil {
+			t.Errorf("Set(%q): expected error, got nil", in)
+		}
+	}
+}
+
+func TestExecute_rainWithinDuration(t *testing.T) {
+	if err := execute([]string{"rain", "--zip", "8000", "--within", "25h"}); err == nil {
+		t.Error("expected error for --within 25h, got nil")
+	}
+}
+
+func TestExecute_rainInvalidAt(t *testing.T) {
+	if err := execute([]string{"rain", "--zip", "8000", "--at", "someday"}); err == nil {
+		t.Error("expected error for --at someday, got nil")
+	}
+}
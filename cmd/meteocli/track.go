@@ -0,0 +1,459 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/geo"
+	"github.com/a-fgx/meteoswiss-cli/internal/gpx"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// trackSegment is a stretch of a track that maps to a single postal locality.
+type trackSegment struct {
+	PLZ            int          `json:"plz"`
+	Locality       string       `json:"locality"`
+	DistanceKM     float64      `json:"distance_km"`
+	Arrive         time.Time    `json:"arrive"`
+	Leave          time.Time    `json:"leave"`
+	RainMM         float64      `json:"rain_mm"`
+	RainPeakMMH    float64      `json:"rain_peak_mm_h"`
+	RainSource     string       `json:"rain_source"`
+	TemperatureMin float64      `json:"temperature_min"`
+	TemperatureMax float64      `json:"temperature_max"`
+	Warnings       warningViews `json:"warnings"`
+}
+
+// trackResult is the structured result for the track command.
+type trackResult struct {
+	File         string         `json:"file"`
+	Start        time.Time      `json:"start"`
+	Finish       time.Time      `json:"finish"`
+	SpeedKMH     float64        `json:"speed_kmh"`
+	DistanceKM   float64        `json:"distance_km"`
+	Segments     []trackSegment `json:"segments"`
+	WorstSegment int            `json:"worst_segment"`
+	Summary      string         `json:"summary"`
+	Units        *unitsInfo     `json:"units,omitempty"`
+}
+
+// inUnits converts the segment temperatures; distances, speeds and rain
+// amounts keep the units in their names.
+func (r trackResult) inUnits(u units.System) any {
+	r.Segments = append([]trackSegment(nil), r.Segments...)
+	for i := range r.Segments {
+		r.Segments[i].TemperatureMin = u.Temp(r.Segments[i].TemperatureMin)
+		r.Segments[i].TemperatureMax = u.Temp(r.Segments[i].TemperatureMax)
+	}
+	r.Units = newUnitsInfo(u)
+	return r
+}
+
+// Table lists one segment per row for csv, tsv and markdown, with the
+// number of active warnings and their highest level.
+func (r trackResult) Table() out.Table {
+	tbl := out.Table{Columns: []string{
+		"plz", "locality", "distance_km", "arrive", "leave", "rain_mm", "rain_peak_mm_h", "rain_source",
+		"temperature_min", "temperature_max", "warnings", "warn_level_max",
+	}}
+	for _, seg := range r.Segments {
+		tbl.Rows = append(tbl.Rows, []string{
+			intCell(seg.PLZ), seg.Locality, numberCell(seg.DistanceKM), timeCell(&seg.Arrive), timeCell(&seg.Leave),
+			numberCell(seg.RainMM), numberCell(seg.RainPeakMMH), seg.RainSource,
+			numberCell(seg.TemperatureMin), numberCell(seg.TemperatureMax),
+			intCell(len(seg.Warnings)), intCell(maxWarnLevel(seg.Warnings.warnings())),
+		})
+	}
+	return tbl
+}
+
+// rawView drops the derived warning fields.
+func (r trackResult) rawView() any {
+	segs := make([]trackSegment, len(r.Segments))
+	for i, seg := range r.Segments {
+		raw := make(warningViews, len(seg.Warnings))
+		for j, w := range seg.Warnings {
+			raw[j] = warningView{Warning: w.Warning}
+		}
+		seg.Warnings = raw
+		segs[i] = seg
+	}
+	r.Segments = segs
+	return r
+}
+
+func newTrackCmd(flags *rootFlags) *cobra.Command {
+	var file string
+	var start string
+	var speed string
+
+	cmd := &cobra.Command{
+		Use:   "track",
+		Short: "Show the forecast along a GPX track at the estimated arrival times",
+		Example: `  # Hike starting tomorrow morning at 4 km/h
+  meteocli track --gpx hike.gpx --start "2026-10-18 08:00" --speed 4km/h
+
+  # Bike ride starting now, as JSON
+  meteocli track --gpx ride.gpx --speed 18km/h --json`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kmh, err := parseSpeed(speed)
+			if err != nil {
+				return err
+			}
+			startAt, err := parseAt(start, flags.now(), flags.loc)
+			if err != nil {
+				return fmt.Errorf("--start: %w", err)
+			}
+			pts, err := gpx.ParseFile(file)
+			if err != nil {
+				return err
+			}
+
+			segs, distKM, skipped := buildTrackSegments(pts, startAt, kmh)
+			if len(segs) == 0 {
+				return fmt.Errorf("%s: no track point is within %.0f km of a Swiss locality", file, maxTrackOffsetKM)
+			}
+			if skipped > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipped %d track point(s) more than %.0f km from any Swiss locality\n", skipped, maxTrackOffsetKM)
+			}
+
+			result := trackResult{
+				File:     filepath.Base(file),
+				Start:    startAt,
+				SpeedKMH: kmh,
+				Segments: segs,
+			}
+
+			details := make(map[int]*api.PLZDetail)
+			for i := range result.Segments {
+				seg := &result.Segments[i]
+				detail, ok := details[seg.PLZ]
+				if !ok {
+					detail, err = flags.fetchDetail(seg.PLZ)
+					if err != nil {
+						return err
+					}
+					details[seg.PLZ] = detail
+				}
+				forecastSegment(seg, detail)
+			}
+
+			last := result.Segments[len(result.Segments)-1]
+			result.Finish = last.Leave
+			result.DistanceKM = distKM
+			result.WorstSegment = worstSegment(result.Segments)
+			result.Summary = segmentSummary(result.Segments[result.WorstSegment], flags.units)
+
+			return flags.render(cmd.OutOrStdout(), result, func(w io.Writer) {
+				printTrack(w, flags.palette, flags.units, result)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "gpx", "", "GPX file with the planned track or route")
+	cmd.Flags().StringVar(&start, "start", "", `departure time in the --tz zone, e.g. "08:00", "tomorrow 07:30", "2026-10-18 08:00" or "+1h" (default now)`)
+	cmd.Flags().StringVar(&speed, "speed", "4km/h", "average speed, e.g. 4km/h or 1.2m/s")
+	_ = cmd.MarkFlagRequired("gpx")
+	return cmd
+}
+
+// parseSpeed parses a speed such as "4km/h", "4.5 kmh", "1.2m/s" or a bare
+// number (km/h) and returns it in km/h.
+func parseSpeed(s string) (float64, error) {
+	v := strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	factor := 1.0
+	switch {
+	case strings.HasSuffix(v, "km/h"):
+		v = strings.TrimSuffix(v, "km/h")
+	case strings.HasSuffix(v, "kmh"):
+		v = strings.TrimSuffix(v, "kmh")
+	case strings.HasSuffix(v, "m/s"):
+		v = strings.TrimSuffix(v, "m/s")
+		factor = 3.6
+	}
+	kmh, err := strconv.ParseFloat(v, 64)
+	if err != nil || kmh <= 0 {
+		return 0, fmt.Errorf("invalid --speed %q: want a positive speed such as 4km/h or 1.2m/s", s)
+	}
+	return kmh * factor, nil
+}
+
+// maxTrackOffsetKM is the farthest a track point may be from the nearest
+// locality of the gazetteer. Points farther away are outside Switzerland, or
+// too far from any listed locality for its forecast to apply.
+const maxTrackOffsetKM = 40.0
+
+// buildTrackSegments walks the track at a constant speed and splits it into
+// segments, one per run of consecutive points sharing the nearest locality.
+// Points more than maxTrackOffsetKM from any locality are skipped and end
+// the current segment; skipped is their number. distKM is the length of the
+// whole track, including skipped stretches.
+func buildTrackSegments(pts []gpx.Point, start time.Time, kmh float64) (segs []trackSegment, distKM float64, skipped int) {
+	var dist float64
+	gap := false
+	for i, p := range pts {
+		if i > 0 {
+			prev := pts[i-1]
+			dist += geo.DistanceKM(prev.Lat, prev.Lon, p.Lat, p.Lon)
+		}
+		at := start.Add(time.Duration(dist / kmh * float64(time.Hour)))
+		loc, km := geo.Nearest(p.Lat, p.Lon)
+		if km > maxTrackOffsetKM {
+			skipped++
+			gap = true
+			continue
+		}
+
+		if n := len(segs); n > 0 && !gap {
+			segs[n-1].Leave = at
+			if segs[n-1].PLZ == loc.PLZ {
+				continue
+			}
+		}
+		gap = false
+		segs = append(segs, trackSegment{
+			PLZ:        loc.PLZ,
+			Locality:   loc.Name,
+			DistanceKM: dist,
+			Arrive:     at,
+			Leave:      at,
+		})
+	}
+	return segs, dist, skipped
+}
+
+// forecastSegment fills in the rain, temperature and warnings expected while
+// passing through seg. Graph data is preferred; the daily forecast for the
+// arrival date is used when the segment lies outside the graph.
+func forecastSegment(seg *trackSegment, detail *api.PLZDetail) {
+	window := seg.Leave.Sub(seg.Arrive)
+	if window < hiInterval {
+		window = hiInterval
+	}
+	end := seg.Arrive.Add(window)
+
+	day, hasDay := forecastForDate(detail.Forecast, seg.Arrive)
+	if mmh, ok := graphRainInWindow(detail.Graph, seg.Arrive, window); ok {
+		seg.RainMM = detail.Graph.PrecipitationSeries().Window(seg.Arrive, end).Total()
+		seg.RainPeakMMH = mmh
+		seg.RainSource = rainSourceNowcast
+	}
+	if seg.RainSource == "" && hasDay {
+		seg.RainMM = day.Precipitation
+		seg.RainSource = rainSourceDaily
+	}
+
+	if lo, hi, ok := graphTemperatureRange(detail.Graph, seg.Arrive, end); ok {
+		seg.TemperatureMin, seg.TemperatureMax = lo, hi
+	} else if hasDay {
+		seg.TemperatureMin, seg.TemperatureMax = day.TemperatureMin, day.TemperatureMax
+	}
+
+	seg.Warnings = newWarningViews(seg.PLZ, activeWarnings(detail.Warnings, seg.Arrive, end), seg.Arrive.Location())
+}
+
+// forecastForDate returns the daily forecast for the Swiss date of t.
+func forecastForDate(days []api.DayForecast, t time.Time) (api.DayForecast, bool) {
+	date := api.DateOf(t.In(zurich))
+	for _, d := range days {
+		if dd, err := d.Date(); err == nil && dd == date {
+			return d, true
+		}
+	}
+	return api.DayForecast{}, false
+}
+
+// graphTemperatureRange returns the lowest and highest hourly mean temperature
+// across the hourly slots that overlap [from, to].
+func graphTemperatureRange(g *api.GraphData, from, to time.Time) (lo, hi float64, ok bool) {
+	if g == nil || g.Start == 0 {
+		return 0, 0, false
+	}
+	start := time.UnixMilli(g.Start)
+	for i, temp := range g.TemperatureMean1h {
+		slotStart := start.Add(time.Duration(i) * loInterval)
+		if slotStart.After(to) {
+			break
+		}
+		if !slotStart.Add(loInterval).After(from) {
+			continue
+		}
+		if !ok || temp < lo {
+			lo = temp
+		}
+		if !ok || temp > hi {
+			hi = temp
+		}
+		ok = true
+	}
+	return lo, hi, ok
+}
+
+// activeWarnings returns the warnings whose validity overlaps [from, to].
+// Warnings with validity times that cannot be parsed are kept.
+func activeWarnings(warnings []api.Warning, from, to time.Time) []api.Warning {
+	active := []api.Warning{}
+	for _, w := range warnings {
+		validFrom, errFrom := time.Parse(time.RFC3339, w.ValidFrom)
+		validTo, errTo := time.Parse(time.RFC3339, w.ValidTo)
+		if errFrom == nil && validFrom.After(to) {
+			continue
+		}
+		if errTo == nil && !validTo.After(from) {
+			continue
+		}
+		active = append(active, w)
+	}
+	return active
+}
+
+// worstSegment returns the index of the segment with the highest warning
+// level, breaking ties by the rain expected while passing through and then
+// by the lowest temperature.
+func worstSegment(segs []trackSegment) int {
+	worst := 0
+	for i := 1; i < len(segs); i++ {
+		a, b := segs[i], segs[worst]
+		la, lb := maxWarnLevel(a.Warnings.warnings()), maxWarnLevel(b.Warnings.warnings())
+		switch {
+		case la != lb:
+			if la > lb {
+				worst = i
+			}
+		case segmentRainMM(a) != segmentRainMM(b):
+			if segmentRainMM(a) > segmentRainMM(b) {
+				worst = i
+			}
+		case a.TemperatureMin < b.TemperatureMin:
+			worst = i
+		}
+	}
+	return worst
+}
+
+// segmentRainMM returns the rain expected while passing through seg. Daily
+// totals are prorated to the time spent in the segment, so that they compare
+// with the nowcast amounts of other segments.
+func segmentRainMM(seg trackSegment) float64 {
+	if seg.RainSource != rainSourceDaily {
+		return seg.RainMM
+	}
+	return seg.RainMM * float64(max(seg.Leave.Sub(seg.Arrive), hiInterval)) / float64(24*time.Hour)
+}
+
+// maxWarnLevel returns the highest WarnLevel in warnings, or 0 if none.
+func maxWarnLevel(warnings []api.Warning) int {
+	level := 0
+	for _, w := range warnings {
+		if w.WarnLevel > level {
+			level = w.WarnLevel
+		}
+	}
+	return level
+}
+
+// segmentSummary describes a segment in one sentence, in the units u.
+func segmentSummary(seg trackSegment, u units.System) string {
+	s := fmt.Sprintf("Worst segment: %s–%s near %s (%d): %s rain, %.0f to %.0f %s",
+		seg.Arrive.Format("15:04"), seg.Leave.Format("15:04"), seg.Locality, seg.PLZ,
+		precipAmount(u, seg.RainMM), u.Temp(seg.TemperatureMin), u.Temp(seg.TemperatureMax), u.TempUnit())
+	if level := maxWarnLevel(seg.Warnings.warnings()); level > 0 {
+		for _, w := range seg.Warnings {
+			if w.WarnLevel == level {
+				s += fmt.Sprintf(", %s warning (%s)", warnTypeLabel(w.WarnType), warnLevelLabel(w.WarnLevel))
+				break
+			}
+		}
+	}
+	return s
+}
+
+func printTrack(w io.Writer, p out.Palette, u units.System, r trackResult) {
+	tbl := out.TextTable{
+		Columns: []out.Column{
+			{Header: ""},
+			{Header: "From"},
+			{Header: "To"},
+			{Header: "km", Right: true, Drop: 2},
+			{Header: "Locality", Min: 12},
+			{Header: "Rain " + u.PrecipUnit(), Right: true},
+			{Header: "Temp " + u.TempUnit(), Right: true},
+			{Header: "Warnings", Drop: 1},
+		},
+	}
+	anyDaily := false
+	for i, seg := range r.Segments {
+		marker := ""
+		if i == r.WorstSegment {
+			marker = "!"
+		}
+		rain := "—"
+		if seg.RainSource != "" {
+			rain = u.FormatPrecip(seg.RainMM)
+			if seg.RainSource == rainSourceDaily {
+				rain += "*"
+				anyDaily = true
+			}
+			rain = p.Rain(rain, seg.RainMM >= wetMM)
+		}
+		warn := "—"
+		if level := maxWarnLevel(seg.Warnings.warnings()); level > 0 {
+			warn = p.Level(fmt.Sprintf("%d (level %d)", len(seg.Warnings), level), level)
+		}
+		tbl.Rows = append(tbl.Rows, []string{
+			marker,
+			seg.Arrive.Format("15:04"),
+			seg.Leave.Format("15:04"),
+			fmt.Sprintf("%.1f", seg.DistanceKM),
+			fmt.Sprintf("%s (%d)", seg.Locality, seg.PLZ),
+			rain,
+			p.Temp(fmt.Sprintf("%.0f", u.Temp(seg.TemperatureMin)), seg.TemperatureMin) + "/" + p.Temp(fmt.Sprintf("%.0f", u.Temp(seg.TemperatureMax)), seg.TemperatureMax),
+			warn,
+		})
+	}
+	width := out.Width(w)
+	header, rows, used := tbl.Layout(w)
+	sep := min(max(76, used), width)
+
+	out.Sep(w, sep)
+	fmt.Fprintf(w, "  Track %s  %.1f km at %.1f km/h  %s → %s\n",
+		r.File, r.DistanceKM, r.SpeedKMH, r.Start.Format("Mon 15:04"), r.Finish.Format("15:04"))
+	out.Sep(w, sep)
+	fmt.Fprintln(w, header)
+	out.Sep(w, sep)
+	for _, row := range rows {
+		fmt.Fprintln(w, row)
+	}
+	out.Sep(w, sep)
+	for _, line := range out.Wrap(out.Transliterate(w, r.Summary), width-2) {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	if anyDaily {
+		fmt.Fprintln(w, "  * daily total; the segment lies outside the hourly forecast")
+	}
+	out.Sep(w, sep)
+}
+
+// warnTypeLabel returns the human-readable name of a warning type.
+func warnTypeLabel(t int) string {
+	if s := api.WarnType[t]; s != "" {
+		return s
+	}
+	return fmt.Sprintf("Type %d", t)
+}
+
+// warnLevelLabel returns the human-readable name of a warning level.
+func warnLevelLabel(l int) string {
+	if s := api.WarnLevel[l]; s != "" {
+		return s
+	}
+	return fmt.Sprintf("Level %d", l)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/gpx"
+)
+
+// --- parseSpeed ---
+
+func TestParseSpeed(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+	}{
+		{"4km/h", 4},
+		{"4.5 km/h", 4.5},
+		{"18kmh", 18},
+		{"5", 5},
+		{"1m/s", 3.6},
+	}
+	for _, tc := range cases {
+		got, err := parseSpeed(tc.in)
+		if err != nil {
+			t.Errorf("parseSpeed(%q) unexpected error: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseSpeed(%q) = %.2f, want %.2f", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseSpeed_invalid(t *testing.T) {
+	for _, in := range []string{"", "fast", "0km/h", "-3"} {
+		if _, err := parseSpeed(in); err == nil {
+			t.Errorf("parseSpeed(%q) expected error, got nil", in)
+		}
+	}
+}
+
+// --- buildTrackSegments ---
+
+func TestBuildTrackSegments_groupsByLocality(t *testing.T) {
+	pts := []gpx.Point{
+		{Lat: 46.6242, Lon: 8.0414}, // Grindelwald
+		{Lat: 46.6250, Lon: 8.0300}, // still Grindelwald
+		{Lat: 46.6083, Lon: 7.9219}, // Wengen
+	}
+	segs, distKM, skipped := buildTrackSegments(pts, anchor, 4)
+	if len(segs) != 2 || skipped != 0 {
+		t.Fatalf("len(segments) = %d, skipped %d; want 2, 0", len(segs), skipped)
+	}
+	if segs[0].PLZ != 3818 || segs[1].PLZ != 3823 {
+		t.Errorf("segment PLZs = %d, %d; want 3818, 3823", segs[0].PLZ, segs[1].PLZ)
+	}
+	if !segs[0].Arrive.Equal(anchor) {
+		t.Errorf("first segment arrives at %v, want %v", segs[0].Arrive, anchor)
+	}
+	if !segs[0].Leave.Equal(segs[1].Arrive) {
+		t.Errorf("first segment leaves at %v, want the next arrival %v", segs[0].Leave, segs[1].Arrive)
+	}
+	if distKM < 8.5 || distKM > 10 {
+		t.Errorf("distance = %.1f km, want ≈9.3", distKM)
+	}
+	// Grindelwald → Wengen is roughly 9.3 km, about 2h20 at 4 km/h.
+	if d := segs[1].Arrive.Sub(anchor); d < 130*time.Minute || d > 150*time.Minute {
+		t.Errorf("arrival in Wengen after %v, want ≈2h20", d)
+	}
+}
+
+func TestBuildTrackSegments_skipsPointsAbroad(t *testing.T) {
+	pts := []gpx.Point{
+		{Lat: 46.6242, Lon: 8.0414}, // Grindelwald
+		{Lat: 48.8566, Lon: 2.3522}, // Paris
+		{Lat: 46.6250, Lon: 8.0300}, // back in Grindelwald
+	}
+	segs, _, skipped := buildTrackSegments(pts, anchor, 4)
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want 1", skipped)
+	}
+	if len(segs) != 2 || segs[0].PLZ != 3818 || segs[1].PLZ != 3818 {
+		t.Fatalf("segments = %+v, want Grindelwald twice, split by the gap", segs)
+	}
+	if !segs[0].Leave.Equal(anchor) {
+		t.Errorf("first segment leaves at %v, want %v: the time abroad is not part of it", segs[0].Leave, anchor)
+	}
+}
+
+func TestExecute_trackFarFromSwitzerland(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "paris.gpx")
+	gpxData := `<gpx version="1.1"><trk><trkseg>
+<trkpt lat="48.8566" lon="2.3522"></trkpt>
+<trkpt lat="48.8600" lon="2.3400"></trkpt>
+</trkseg></trk></gpx>`
+	if err := os.WriteFile(file, []byte(gpxData), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := execute([]string{"track", "--gpx", file})
+	if err == nil || !strings.Contains(err.Error(), "within 40 km") {
+		t.Errorf("err = %v, want no point within 40 km", err)
+	}
+}
+
+// --- forecastSegment ---
+
+func TestForecastSegment_usesGraph(t *testing.T) {
+	g := makeGraph([]float64{0, 1.5, 0, 0, 0, 0}, nil)
+	g.TemperatureMean1h = []float64{3, 5}
+	seg := trackSegment{Arrive: anchor, Leave: anchor.Add(30 * time.Minute)}
+	forecastSegment(&seg, &api.PLZDetail{Graph: g})
+
+	if seg.RainSource != "nowcast" || seg.RainMM != 1.5 {
+		t.Errorf("rain = %.1f (%s), want 1.5 (nowcast)", seg.RainMM, seg.RainSource)
+	}
+	if seg.TemperatureMin != 3 || seg.TemperatureMax != 3 {
+		t.Errorf("temperature = %.0f..%.0f, want 3..3", seg.TemperatureMin, seg.TemperatureMax)
+	}
+}
+
+func TestForecastSegment_fallsBackToDaily(t *testing.T) {
+	detail := &api.PLZDetail{
+		Forecast: []api.DayForecast{
+			{DayDate: "2026-02-20", Precipitation: 4.2, TemperatureMin: -2, TemperatureMax: 6},
+		},
+	}
+	seg := trackSegment{Arrive: anchor, Leave: anchor.Add(time.Hour)}
+	forecastSegment(&seg, detail)
+
+	if seg.RainSource != "daily" || seg.RainMM != 4.2 {
+		t.Errorf("rain = %.1f (%s), want 4.2 (daily)", seg.RainMM, seg.RainSource)
+	}
+	if seg.TemperatureMin != -2 || seg.TemperatureMax != 6 {
+		t.Errorf("temperature = %.0f..%.0f, want -2..6", seg.TemperatureMin, seg.TemperatureMax)
+	}
+}
+
+// --- activeWarnings ---
+
+func TestActiveWarnings_filtersByValidity(t *testing.T) {
+	warnings := []api.Warning{
+		{WarnType: 0, ValidFrom: "2026-02-20T10:00:00Z", ValidTo: "2026-02-20T11:00:00Z"},
+		{WarnType: 1, ValidFrom: "2026-02-20T11:30:00Z", ValidTo: "2026-02-20T14:00:00Z"},
+		{WarnType: 2, ValidFrom: "tonight"},
+	}
+	from := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	got := activeWarnings(warnings, from, from.Add(time.Hour))
+	if len(got) != 2 {
+		t.Fatalf("len(active) = %d, want 2", len(got))
+	}
+	if got[0].WarnType != 1 || got[1].WarnType != 2 {
+		t.Errorf("active warning types = %d, %d; want 1, 2", got[0].WarnType, got[1].WarnType)
+	}
+}
+
+// --- worstSegment ---
+
+func TestWorstSegment(t *testing.T) {
+	segs := []trackSegment{
+		{RainMM: 5},
+		{RainMM: 0.2, Warnings: newWarningViews(8000, []api.Warning{{WarnLevel: 3}}, zurich)},
+		{RainMM: 8},
+	}
+	if got := worstSegment(segs); got != 1 {
+		t.Errorf("worstSegment() = %d, want 1 (highest warning level)", got)
+	}
+	segs[1].Warnings = nil
+	if got := worstSegment(segs); got != 2 {
+		t.Errorf("worstSegment() = %d, want 2 (most rain)", got)
+	}
+}
+
+func TestWorstSegment_mixedSources(t *testing.T) {
+	// A shower in the nowcast is worse than a day's total spread over the
+	// hour spent in a segment outside the graph data.
+	segs := []trackSegment{
+		{RainMM: 1.5, RainSource: rainSourceNowcast, Arrive: anchor, Leave: anchor.Add(time.Hour)},
+		{RainMM: 6, RainSource: rainSourceDaily, Arrive: anchor.Add(time.Hour), Leave: anchor.Add(2 * time.Hour)},
+	}
+	if got := worstSegment(segs); got != 0 {
+		t.Errorf("worstSegment() = %d, want 0 (nowcast shower)", got)
+	}
+	segs[1].RainMM = 48
+	if got := worstSegment(segs); got != 1 {
+		t.Errorf("worstSegment() = %d, want 1 (2 mm in its hour)", got)
+	}
+}
+
+// --- CLI validation ---
+
+func TestExecute_trackMissingGPX(t *testing.T) {
+	if err := execute([]string{"track"}); err == nil {
+		t.Error("expected error when --gpx is missing, got nil")
+	}
+}
+
+func TestExecute_trackInvalidSpeed(t *testing.T) {
+	if err := execute([]string{"track", "--gpx", "hike.gpx", "--speed", "0"}); err == nil {
+		t.Error("expected error for --speed 0, got nil")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// unitConverter is implemented by results whose structured output can be
+// converted to the units selected with --units. Fields whose name carries a
+// unit, such as rain_mm, keep that unit.
+type unitConverter interface {
+	inUnits(u units.System) any
+}
+
+// unitsInfo records the units of converted structured output.
+type unitsInfo struct {
+	Name string `json:"system"`
+	units.System
+}
+
+func newUnitsInfo(u units.System) *unitsInfo {
+	return &unitsInfo{Name: u.Name(), System: u}
+}
+
+// resolveUnits parses --units and the per-quantity overrides. Structured
+// output is only converted when one of them is given, so that it keeps the
+// API's metric units by default.
+func (f *rootFlags) resolveUnits(cmd *cobra.Command) error {
+	u, err := units.Parse(f.unitSystem, f.tempUnit, f.precipUnit, f.windUnit)
+	if err != nil {
+		return err
+	}
+	f.units = u
+	for _, name := range []string{"units", "temp-unit", "precip-unit", "wind-unit"} {
+		if cmd.Flags().Changed(name) {
+			f.unitsSet = true
+		}
+	}
+	return nil
+}
+
+// windView is the wind at the time of a result, in km/h unless converted.
+type windView struct {
+	Speed          float64 `json:"speed"`
+	Gust           float64 `json:"gust,omitempty"`
+	Direction      *int    `json:"direction,omitempty"`
+	DirectionLabel string  `json:"directionLabel,omitempty"`
+}
+
+func newWindView(w api.Wind) *windView {
+	v := &windView{Speed: w.Speed, Gust: w.Gust}
+	if w.Direction >= 0 {
+		dir := w.Direction
+		v.Direction = &dir
+		v.DirectionLabel = api.WindDirectionLabel(dir)
+	}
+	return v
+}
+
+func (v *windView) inUnits(u units.System) *windView {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	c.Speed = u.WindSpeed(v.Speed)
+	c.Gust = u.WindSpeed(v.Gust)
+	return &c
+}
+
+// temperature formats a temperature in °C in the display unit, e.g.
+// "12.5 °C" or "54.5 °F".
+func temperature(u units.System, c float64) string {
+	return u.FormatTemp(c) + " " + u.TempUnit()
+}
+
+// precipAmount formats an amount in mm in the display unit, e.g. "2.4 mm"
+// or "0.09 in".
+func precipAmount(u units.System, mm float64) string {
+	return u.FormatPrecip(mm) + " " + u.PrecipUnit()
+}
+
+// precipRate formats a rate in mm/h in the display unit, e.g. "2.4 mm/h".
+// extra adds decimals for small rates.
+func precipRate(u units.System, mmh float64, extra int) string {
+	return fmt.Sprintf("%.*f %s/h", u.PrecipDecimals()+extra, u.Precip(mmh), u.PrecipUnit())
+}
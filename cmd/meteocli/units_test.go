@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/cache"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// windyDetail has 20 °C, 10 mm today and a 36 km/h south-westerly.
+func windyDetail() *api.PLZDetail {
+	return &api.PLZDetail{
+		CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: 20},
+		Forecast:       []api.DayForecast{{DayDate: "2026-02-20", TemperatureMin: 10, TemperatureMax: 25, Precipitation: 10}},
+		Graph: &api.GraphData{
+			StartLowResolution: anchor.UnixMilli(),
+			WindSpeed3h:        []float64{36},
+			GustSpeed3h:        []float64{72},
+			WindDirection3h:    []int{225},
+		},
+	}
+}
+
+// --- render: unit conversion ---
+
+func TestRender_unitsConvertWhenSet(t *testing.T) {
+	view := newWeatherView(8000, windyDetail(), anchor)
+	for _, set := range []bool{false, true} {
+		flags := rootFlags{format: out.FormatJSON, units: units.System{Temperature: units.Fahrenheit, Wind: units.MS}, unitsSet: set}
+		var buf bytes.Buffer
+		if err := flags.render(&buf, view, nil); err != nil {
+			t.Fatal(err)
+		}
+		var got struct {
+			Temperature float64
+			Wind        windView
+			Units       *struct{ System, Temperature, Wind string }
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case !set && (got.Temperature != 20 || got.Wind.Speed != 36 || got.Units != nil):
+			t.Errorf("without --units: %s", buf.String())
+		case set && (got.Temperature != 68 || got.Wind.Speed != 10 || got.Wind.Gust != 20 ||
+			got.Units == nil || got.Units.System != "custom" || got.Units.Temperature != "F" || got.Units.Wind != "m/s"):
+			t.Errorf("with --units: %s", buf.String())
+		}
+		if got.Wind.DirectionLabel != "SW" {
+			t.Errorf("wind direction = %q, want SW", got.Wind.DirectionLabel)
+		}
+	}
+}
+
+func TestDayViews_inUnitsLeavesOriginal(t *testing.T) {
+	views := newDayViews(8000, windyDetail().Forecast)
+	converted := views.inUnits(units.Imperial).(dayViews)
+	if converted[0].TemperatureMax != 77 || converted[0].Precipitation != 10/25.4 {
+		t.Errorf("converted day = %+v", converted[0].DayForecast)
+	}
+	if views[0].TemperatureMax != 25 || views[0].Units != nil {
+		t.Errorf("original day changed: %+v", views[0])
+	}
+}
+
+// --- text output ---
+
+func TestPrintCurrentWeather_units(t *testing.T) {
+	var buf bytes.Buffer
+	printCurrentWeather(&buf, out.Palette{}, units.Imperial, 8000, windyDetail(), anchor)
+	for _, want := range []string{
+		"Temperature : 68.0 °F\n",
+		"Wind        : 22 mph from SW, gusts 45 mph\n",
+		"Today       : 50.0 / 77.0 °F  rain 0.39 in\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestCheckRain_messageInUnits(t *testing.T) {
+	detail := &api.PLZDetail{Graph: makeGraph([]float64{0, 0.5, 0.5, 0}, nil)}
+	r := checkRain(8000, 30, defaultRainThreshold, detail, anchor, units.Imperial)
+	if !strings.Contains(r.Message, "peak 0.12 in/h") {
+		t.Errorf("Message = %q, want the peak in in/h", r.Message)
+	}
+}
+
+// --- run: --units ---
+
+func TestRun_units(t *testing.T) {
+	stubRefresh(t)
+	_ = cache.Write(detailCacheKey(8000), &api.PLZDetail{CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: 20}})
+
+	var stdout bytes.Buffer
+	if err := run([]string{"prompt", "--loc", "home", "--units", "imperial"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := api.IconEmoji(1) + " 68°\n"; stdout.String() != want {
+		t.Errorf("output = %q, want %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	if err := run([]string{"prompt", "--loc", "home", "--temp-unit", "K", "--fields", "temperature,units.system"}, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "temperature:   293.15\nunits.system:  custom\n"; stdout.String() != want {
+		t.Errorf("output = %q, want %q", stdout.String(), want)
+	}
+
+	for _, args := range [][]string{
+		{"version", "--units", "nautical"},
+		{"version", "--wind-unit", "furlongs"},
+	} {
+		if err := run(args, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+			t.Errorf("%v: expected error, got nil", args)
+		}
+	}
+}
@@ -2,17 +2,25 @@ package main
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 )
 
-func newVersionCmd() *cobra.Command {
+// versionInfo is the structured result for the version command.
+type versionInfo struct {
+	Version string `json:"version"`
+}
+
+func newVersionCmd(flags *rootFlags) *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Print the version number",
-		Args:  cobra.NoArgs,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("meteocli %s\n", version)
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return flags.render(cmd.OutOrStdout(), versionInfo{Version: version}, func(w io.Writer) {
+				fmt.Fprintf(w, "meteocli %s\n", version)
+			})
 		},
 	}
 }
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/a-fgx/meteoswiss-cli/internal/api"
@@ -12,6 +14,7 @@ import (
 func newWarningsCmd(flags *rootFlags) *cobra.Command {
 	var plz int
 	var warnLevel int
+	var oneline bool
 
 	cmd := &cobra.Command{
 		Use:   "warnings",
@@ -23,7 +26,11 @@ func newWarningsCmd(flags *rootFlags) *cobra.Command {
   meteocli warnings --zip 3000 --min-level 3
 
   # Output as JSON
-  meteocli warnings --zip 3000 --json`,
+  meteocli warnings --zip 3000 --json
+
+  # Summary line with the most severe warning of level 3 and above
+  meteocli warnings --zip 3000 --min-level 3 --oneline`,
+		Args: noArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if warnLevel < 1 || warnLevel > 5 {
 				return fmt.Errorf("--min-level must be between 1 and 5")
@@ -32,68 +39,103 @@ func newWarningsCmd(flags *rootFlags) *cobra.Command {
 				return err
 			}
 
-			client := api.New()
-			detail, err := client.PLZDetail(plz)
+			detail, err := flags.fetchDetail(plz)
 			if err != nil {
 				return err
 			}
 
-			// Filter by minimum level.
-			var filtered []api.Warning
+			if oneline {
+				return flags.renderOneline(cmd.OutOrStdout(), plz, detail, flags.now(), warnLevel)
+			}
+
+			// Filter by minimum level; an empty list, not null, when
+			// nothing matches.
+			filtered := []api.Warning{}
 			for _, w := range detail.Warnings {
 				if w.WarnLevel >= warnLevel {
 					filtered = append(filtered, w)
 				}
 			}
 
-			if flags.asJSON {
-				return out.PrintJSON(os.Stdout, filtered)
-			}
-
-			printWarnings(filtered)
-			return nil
+			return flags.render(cmd.OutOrStdout(), newWarningViews(plz, filtered, flags.loc), func(w io.Writer) {
+				printWarnings(w, flags.palette, filtered, flags.now())
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&plz, "zip", 0, "Swiss postal code (e.g. 3000 for Bern)")
 	cmd.Flags().IntVar(&warnLevel, "min-level", 1, "minimum warning level to display (1=Minor … 5=Very high)")
+	cmd.Flags().BoolVar(&oneline, "oneline", false, onelineUsage)
 	_ = cmd.MarkFlagRequired("zip")
 	return cmd
 }
 
-func printWarnings(warnings []api.Warning) {
+// Table lists one warning per row for csv, tsv and markdown, with the
+// regions joined by semicolons.
+func (vs warningViews) Table() out.Table {
+	tbl := out.Table{Columns: []string{
+		"plz", "warnType", "warnTypeLabel", "warnLevel", "warnLevelLabel",
+		"validFrom", "validTo", "regions", "headline", "body",
+	}}
+	for _, w := range vs {
+		tbl.Rows = append(tbl.Rows, []string{
+			intCell(w.plz), intCell(w.WarnType), w.WarnTypeLabel, intCell(w.WarnLevel), w.WarnLevelLabel,
+			w.ValidFrom, w.ValidTo, strings.Join(w.Regions, ";"), w.Headline, w.Body,
+		})
+	}
+	return tbl
+}
+
+// printWarnings lists the warnings with their validity in now's location.
+func printWarnings(w io.Writer, p out.Palette, warnings []api.Warning, now time.Time) {
 	if len(warnings) == 0 {
-		out.Println("No active weather warnings.")
+		out.Println(w, "No active weather warnings.")
 		return
 	}
 
-	out.Sep(60)
-	fmt.Printf("  %d active warning(s)\n", len(warnings))
-	out.Sep(60)
-
-	for i, w := range warnings {
-		wtype := api.WarnType[w.WarnType]
-		if wtype == "" {
-			wtype = fmt.Sprintf("Type %d", w.WarnType)
-		}
-		wlevel := api.WarnLevel[w.WarnLevel]
-		if wlevel == "" {
-			wlevel = fmt.Sprintf("Level %d", w.WarnLevel)
+	width := out.Width(w)
+	sep := min(60, width)
+	// Headlines and bodies are wrapped below the "  [n] " prefix.
+	text := func(s string) {
+		for _, line := range out.Wrap(out.Transliterate(w, s), width-6) {
+			fmt.Fprintf(w, "      %s\n", line)
 		}
+	}
+
+	out.Sep(w, sep)
+	fmt.Fprintf(w, "  %d active warning(s)\n", len(warnings))
+	out.Sep(w, sep)
 
-		fmt.Printf("  [%d] %s — %s\n", i+1, wtype, wlevel)
-		if w.Headline != "" {
-			fmt.Printf("      %s\n", w.Headline)
+	for i, warn := range warnings {
+		label := fmt.Sprintf("[%d] %s — %s", i+1, warnTypeLabel(warn.WarnType), warnLevelLabel(warn.WarnLevel))
+		fmt.Fprintf(w, "  %s\n", p.Level(label, warn.WarnLevel))
+		if warn.Headline != "" {
+			text(warn.Headline)
 		}
-		if w.ValidFrom != "" || w.ValidTo != "" {
-			fmt.Printf("      %s → %s\n", w.ValidFrom, w.ValidTo)
+		if warn.ValidFrom != "" || warn.ValidTo != "" {
+			fmt.Fprintf(w, "      %s → %s\n", validLabel(warn.ValidFrom, now), validLabel(warn.ValidTo, now))
 		}
-		if len(w.Regions) > 0 {
-			fmt.Printf("      Regions: %v\n", w.Regions)
+		if len(warn.Regions) > 0 {
+			text("Regions: " + strings.Join(warn.Regions, ", "))
+		}
+		if warn.Body != "" {
+			fmt.Fprintln(w)
+			text(warn.Body)
 		}
 		if i < len(warnings)-1 {
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
-	out.Sep(60)
+	out.Sep(w, sep)
+}
+
+// validLabel formats the start or end of a warning relative to now and in
+// its location, e.g. "Today 18:00 CET". Values that are not RFC 3339 are
+// shown as the API sent them.
+func validLabel(s string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return timeLabel(t, now)
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// --- printWarnings ---
+
+func TestPrintWarnings_wrapsBodyToWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "40")
+	var buf bytes.Buffer
+	printWarnings(&buf, out.Palette{}, []api.Warning{{
+		WarnType:  2,
+		WarnLevel: 3,
+		Headline:  "Heavy rain",
+		Body:      "Between Sunday evening and Monday noon 60 to 80 mm of rain are expected, locally up to 100 mm.",
+	}}, anchor)
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if out.StringWidth(line) > 40 {
+			t.Errorf("line %q is wider than 40 columns", line)
+		}
+	}
+	if !strings.Contains(buf.String(), "      noon 60 to 80 mm of rain are\n") {
+		t.Errorf("body not wrapped with an indent:\n%s", buf.String())
+	}
+}
+
+func TestPrintWarnings_validityInZone(t *testing.T) {
+	// With --tz America/New_York the validity is shown in New York time.
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	var buf bytes.Buffer
+	printWarnings(&buf, out.Palette{}, []api.Warning{{
+		WarnType:  1,
+		WarnLevel: 3,
+		ValidFrom: "2026-02-20T18:00:00+01:00",
+		ValidTo:   "2026-02-21T06:00:00+01:00",
+	}}, time.Date(2026, 2, 20, 9, 0, 0, 0, ny))
+	if want := "      Today 12:00 EST → Tomorrow 00:00 EST\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("output lacks %q:\n%s", want, buf.String())
+	}
+}
+
+func TestPrintWarnings_none(t *testing.T) {
+	var buf bytes.Buffer
+	printWarnings(&buf, out.Palette{}, nil, anchor)
+	if buf.String() != "No active weather warnings.\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestPrintWarnings_textModes(t *testing.T) {
+	warnings := []api.Warning{{
+		WarnType:  1,
+		WarnLevel: 3,
+		Headline:  "Thunderstorms around Zürich",
+		ValidFrom: "2026-07-01T14:00",
+		ValidTo:   "2026-07-01T20:00",
+		Regions:   []string{"Zürich", "Genève"},
+	}}
+
+	var buf bytes.Buffer
+	tw := out.NewTextWriter(&buf, out.TextASCII)
+	printWarnings(tw, out.Palette{}, warnings, anchor)
+	_ = tw.Flush()
+	for _, r := range buf.String() {
+		if r > 0x7e || r < 0x20 && r != '\n' {
+			t.Fatalf("--ascii output contains %q:\n%s", r, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), "  [1] Thunderstorm - Considerable\n") {
+		t.Errorf("--ascii output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	tw = out.NewTextWriter(&buf, out.TextScreenReader)
+	printWarnings(tw, out.Palette{}, warnings, anchor)
+	_ = tw.Flush()
+	want := "1 active warning(s).\n" +
+		"[1] Thunderstorm - Considerable.\n" +
+		"Thunderstorms around Zuerich.\n" +
+		"2026-07-01T14:00 -> 2026-07-01T20:00.\n" +
+		"Regions: Zuerich, Geneve.\n"
+	if buf.String() != want {
+		t.Errorf("--screen-reader output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
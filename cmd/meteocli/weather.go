@@ -2,16 +2,19 @@ package main
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/a-fgx/meteoswiss-cli/internal/api"
 	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
 )
 
 func newWeatherCmd(flags *rootFlags) *cobra.Command {
 	var plz int
+	var oneline bool
 
 	cmd := &cobra.Command{
 		Use:   "weather",
@@ -20,52 +23,177 @@ func newWeatherCmd(flags *rootFlags) *cobra.Command {
   meteocli weather --zip 8000
 
   # Current weather in Bern as JSON
-  meteocli weather --zip 3000 --json`,
+  meteocli weather --zip 3000 --json
+
+  # Waybar custom module ("return-type": "json")
+  meteocli weather --zip 8000 --format waybar
+
+  # One line for a login banner
+  meteocli weather --zip 8000 --oneline`,
+		Args: noArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requirePLZ(plz); err != nil {
 				return err
 			}
 
-			client := api.New()
-			detail, err := client.PLZDetail(plz)
+			detail, err := flags.fetchDetail(plz)
 			if err != nil {
 				return err
 			}
 
-			if flags.asJSON {
-				return out.PrintJSON(os.Stdout, detail.CurrentWeather)
+			now := flags.now()
+			if oneline {
+				return flags.renderOneline(cmd.OutOrStdout(), plz, detail, now, 1)
 			}
-
-			printCurrentWeather(plz, detail)
-			return nil
+			view := newWeatherView(plz, detail, now)
+			return flags.render(cmd.OutOrStdout(), view, func(w io.Writer) {
+				printCurrentWeather(w, flags.palette, flags.units, plz, detail, now)
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&plz, "zip", 0, "Swiss postal code (e.g. 8000 for Zurich)")
+	cmd.Flags().BoolVar(&oneline, "oneline", false, onelineUsage)
 	_ = cmd.MarkFlagRequired("zip")
 	return cmd
 }
 
-func printCurrentWeather(plz int, detail *api.PLZDetail) {
+// weatherView is the result of the weather command. It encodes like
+// api.CurrentWeather plus the observation time in RFC 3339 in the --tz
+// zone, the icon details and the wind; the rest of the detail is kept for
+// the status-bar formats, which also show warnings and imminent rain.
+type weatherView struct {
+	api.CurrentWeather
+	TimeISO *time.Time `json:"timeIso,omitempty"`
+	iconInfo
+	Wind   *windView  `json:"wind,omitempty"`
+	Units  *unitsInfo `json:"units,omitempty"`
+	plz    int
+	detail *api.PLZDetail
+	now    time.Time
+	units  units.System
+}
+
+func newWeatherView(plz int, detail *api.PLZDetail, now time.Time) weatherView {
+	v := weatherView{
+		CurrentWeather: detail.CurrentWeather,
+		iconInfo:       newIconInfo(detail.CurrentWeather.Icon),
+		plz:            plz,
+		detail:         detail,
+		now:            now,
+	}
+	if v.Time != 0 {
+		t := time.UnixMilli(v.Time).In(now.Location())
+		v.TimeISO = &t
+	}
+	if wind, ok := detail.Graph.WindAt(now); ok {
+		v.Wind = newWindView(wind)
+	}
+	return v
+}
+
+func (v weatherView) rawView() any {
+	return v.CurrentWeather
+}
+
+func (v weatherView) inUnits(u units.System) any {
+	v.Temperature = u.Temp(v.Temperature)
+	v.Wind = v.Wind.inUnits(u)
+	v.Units = newUnitsInfo(u)
+	v.units = u
+	return v
+}
+
+// Status summarises the current weather for status bars: icon and
+// temperature, with markers for rain in the next 30 minutes and for active
+// warnings of level 2 and above.
+func (v weatherView) Status() out.Status {
+	warnings := activeWarnings(v.detail.Warnings, v.now, v.now)
+	level := maxWarnLevel(warnings)
+	rain := checkRain(v.plz, 30, defaultRainThreshold, v.detail, v.now, v.units)
+	rainSoon := rain.RainExpected && rain.Source == rainSourceNowcast
+
+	s := out.Status{
+		Text:       fmt.Sprintf("%s %.0f°", api.IconEmoji(v.Icon), v.Temperature),
+		Short:      fmt.Sprintf("%.0f°", v.Temperature),
+		Class:      out.StatusClass(level, rainSoon),
+		Percentage: level * 20,
+	}
+	if rainSoon {
+		s.Text += " ☔"
+	}
+	if level >= 2 {
+		s.Text += " ⚠"
+	}
+
+	tooltip := []string{fmt.Sprintf("PLZ %d: %s, %.1f %s", v.plz, api.IconDescription(v.Icon), v.Temperature, v.units.TempUnit())}
+	if len(v.detail.Forecast) > 0 {
+		today := v.detail.Forecast[0]
+		tooltip = append(tooltip, fmt.Sprintf("Today: %.0f / %.0f %s, %s", v.units.Temp(today.TemperatureMin), v.units.Temp(today.TemperatureMax),
+			v.units.TempUnit(), precipAmount(v.units, today.Precipitation)))
+	}
+	if rain.Source != rainSourceNone {
+		tooltip = append(tooltip, rain.Message)
+	}
+	for _, w := range warnings {
+		line := fmt.Sprintf("⚠ %s — %s", warnTypeLabel(w.WarnType), warnLevelLabel(w.WarnLevel))
+		if w.Headline != "" {
+			line += ": " + w.Headline
+		}
+		tooltip = append(tooltip, line)
+	}
+	s.Tooltip = strings.Join(tooltip, "\n")
+	return s
+}
+
+func printCurrentWeather(w io.Writer, p out.Palette, u units.System, plz int, detail *api.PLZDetail, now time.Time) {
 	cw := detail.CurrentWeather
 	emoji := api.IconEmoji(cw.Icon)
 	desc := api.IconDescription(cw.Icon)
 
-	out.Sep(44)
-	fmt.Printf("  Weather for PLZ %d\n", plz)
-	out.Sep(44)
-	fmt.Printf("  %s (%s)\n", desc, emoji)
-	fmt.Printf("  Temperature : %.1f °C\n", cw.Temperature)
+	// The conditions are drawn next to the icon art.
+	lines := []string{
+		fmt.Sprintf("  %s (%s)", desc, emoji),
+		fmt.Sprintf("  Temperature : %s", p.Temp(temperature(u, cw.Temperature), cw.Temperature)),
+	}
+	if wind, ok := detail.Graph.WindAt(now); ok {
+		lines = append(lines, fmt.Sprintf("  Wind        : %s", windText(u, wind)))
+	}
 	if cw.Time != 0 {
-		fmt.Printf("  Observed at : %s\n", time.UnixMilli(cw.Time).Format("2006-01-02 15:04"))
+		lines = append(lines, fmt.Sprintf("  Observed at : %s", timeLabel(time.UnixMilli(cw.Time), now)))
+	}
+	lines = besideArt(w, cw.Icon, lines)
+
+	sep := min(max(44, linesWidth(w, lines)), out.Width(w))
+
+	out.Sep(w, sep)
+	fmt.Fprintf(w, "  Weather for PLZ %d\n", plz)
+	out.Sep(w, sep)
+	for _, l := range lines {
+		fmt.Fprintln(w, l)
 	}
-	out.Sep(44)
+	out.Sep(w, sep)
 
 	// Show today's forecast summary if available.
 	if len(detail.Forecast) > 0 {
 		today := detail.Forecast[0]
-		fmt.Printf("  Today       : %.1f / %.1f °C  rain %.1f mm\n",
-			today.TemperatureMin, today.TemperatureMax, today.Precipitation)
-		out.Sep(44)
+		fmt.Fprintf(w, "  Today       : %s / %s %s  %s\n",
+			p.Temp(u.FormatTemp(today.TemperatureMin), today.TemperatureMin),
+			p.Temp(u.FormatTemp(today.TemperatureMax), today.TemperatureMax),
+			u.TempUnit(),
+			p.Rain("rain "+precipAmount(u, today.Precipitation), today.Precipitation >= wetMM))
+		out.Sep(w, sep)
+	}
+}
+
+// windText describes the wind, e.g. "14 km/h from SW, gusts 32 km/h".
+func windText(u units.System, wind api.Wind) string {
+	s := u.FormatWind(wind.Speed)
+	if wind.Direction >= 0 {
+		s += " from " + api.WindDirectionLabel(wind.Direction)
+	}
+	if wind.Gust > 0 {
+		s += ", gusts " + u.FormatWind(wind.Gust)
 	}
+	return s
 }
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+	"github.com/a-fgx/meteoswiss-cli/internal/units"
+)
+
+// --- weatherView ---
+
+func TestWeatherView_encodesLikeCurrentWeather(t *testing.T) {
+	cw := api.CurrentWeather{Time: anchor.UnixMilli(), Icon: 1, Temperature: 14.2}
+	view := weatherView{CurrentWeather: cw, plz: 8000, detail: &api.PLZDetail{}, now: anchor}
+	got, _ := json.Marshal(view)
+	want, _ := json.Marshal(cw)
+	if string(got) != string(want) {
+		t.Errorf("JSON = %s, want %s", got, want)
+	}
+}
+
+func TestNewWeatherView_timeInNowsZone(t *testing.T) {
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC).In(zurich)
+	detail := &api.PLZDetail{CurrentWeather: api.CurrentWeather{Time: now.Add(-10 * time.Minute).UnixMilli()}}
+	view := newWeatherView(8000, detail, now)
+	if view.TimeISO == nil || view.TimeISO.Location() != zurich {
+		t.Errorf("TimeISO = %v, want Europe/Zurich time", view.TimeISO)
+	}
+}
+
+func TestWeatherView_status(t *testing.T) {
+	detail := &api.PLZDetail{
+		CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: 14.2},
+		Graph:          makeGraph([]float64{0, 0.5, 0.5}, nil),
+		Warnings: []api.Warning{{
+			WarnType:  1,
+			WarnLevel: 3,
+			ValidFrom: anchor.Add(-time.Hour).Format(time.RFC3339),
+			ValidTo:   anchor.Add(time.Hour).Format(time.RFC3339),
+			Headline:  "Thunderstorms",
+		}},
+	}
+	view := weatherView{CurrentWeather: detail.CurrentWeather, plz: 8000, detail: detail, now: anchor}
+	s := view.Status()
+
+	if want := api.IconEmoji(1) + " 14° ☔ ⚠"; s.Text != want {
+		t.Errorf("Text = %q, want %q", s.Text, want)
+	}
+	if s.Class != out.StatusWarning || s.Percentage != 60 {
+		t.Errorf("Class, Percentage = %q, %d; want %q, 60", s.Class, s.Percentage, out.StatusWarning)
+	}
+	if !strings.Contains(s.Tooltip, "Thunderstorms") || !strings.Contains(s.Tooltip, "Rain from") {
+		t.Errorf("Tooltip = %q, want the warning and the rain message", s.Tooltip)
+	}
+}
+
+func TestWeatherView_statusCalm(t *testing.T) {
+	detail := &api.PLZDetail{
+		CurrentWeather: api.CurrentWeather{Icon: 1, Temperature: -2.6},
+		Graph:          makeGraph([]float64{0, 0, 0}, nil),
+	}
+	s := weatherView{CurrentWeather: detail.CurrentWeather, plz: 8000, detail: detail, now: anchor}.Status()
+	if s.Class != out.StatusNormal || s.Short != "-3°" {
+		t.Errorf("Status() = %+v, want normal class and short text -3°", s)
+	}
+}
+
+// --- printCurrentWeather ---
+
+func TestPrintCurrentWeather_observedAtInZone(t *testing.T) {
+	// On a UTC host the observation must still be shown in the --tz zone.
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	detail := &api.PLZDetail{CurrentWeather: api.CurrentWeather{Time: now.Add(-10 * time.Minute).UnixMilli(), Icon: 1}}
+	for _, tc := range []struct {
+		loc  *time.Location
+		want string
+	}{
+		{zurich, "Observed at : Today 12:50 CET\n"},
+		{time.UTC, "Observed at : Today 11:50 UTC\n"},
+	} {
+		var buf strings.Builder
+		printCurrentWeather(&buf, out.Palette{}, units.Metric, 8000, detail, now.In(tc.loc))
+		if !strings.Contains(buf.String(), tc.want) {
+			t.Errorf("%s: output lacks %q:\n%s", tc.loc, tc.want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+// --- ParseDate ---
+
+func TestParseDate(t *testing.T) {
+	want := Date{Year: 2026, Month: time.October, Day: 18}
+	for _, in := range []string{"2026-10-18", "20261018"} {
+		got, err := ParseDate(in)
+		if err != nil || got != want {
+			t.Errorf("ParseDate(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "18.10.2026", "2026-13-01", "2026-10-18T07:00"} {
+		if _, err := ParseDate(in); err == nil {
+			t.Errorf("ParseDate(%q): expected error, got nil", in)
+		}
+	}
+}
+
+func TestDayForecast_date(t *testing.T) {
+	d, err := DayForecast{DayDate: "20261231"}.Date()
+	if err != nil || d.String() != "2026-12-31" {
+		t.Errorf("Date() = %v, %v; want 2026-12-31", d, err)
+	}
+}
+
+// --- Date ---
+
+func TestDateOf_usesLocation(t *testing.T) {
+	// 23:30 UTC is already the next day in Zurich.
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Skip("Europe/Zurich not available:", err)
+	}
+	at := time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC)
+	if got := DateOf(at); got.Day != 17 {
+		t.Errorf("DateOf(UTC) = %v, want 2026-10-17", got)
+	}
+	if got := DateOf(at.In(zurich)); got.Day != 18 {
+		t.Errorf("DateOf(Zurich) = %v, want 2026-10-18", got)
+	}
+}
+
+func TestDate_arithmetic(t *testing.T) {
+	d := Date{Year: 2026, Month: time.October, Day: 25} // the clocks go back in Zurich
+	if got := d.Weekday(); got != time.Sunday {
+		t.Errorf("Weekday() = %v, want Sunday", got)
+	}
+	next := d.AddDays(7)
+	if next != (Date{Year: 2026, Month: time.November, Day: 1}) {
+		t.Errorf("AddDays(7) = %v, want 2026-11-01", next)
+	}
+	if n := next.DaysSince(d); n != 7 {
+		t.Errorf("DaysSince() = %d, want 7", n)
+	}
+	if n := d.DaysSince(next); n != -7 {
+		t.Errorf("DaysSince() = %d, want -7", n)
+	}
+	if d.IsZero() || !(Date{}).IsZero() {
+		t.Error("IsZero() is wrong")
+	}
+}
@@ -28,12 +28,18 @@ type DayForecast struct {
 
 // GraphData holds precipitation data for the rain command.
 // High-resolution (10-min) data starts at Start; low-resolution (1-hour)
-// data starts at StartLowResolution. All timestamps are Unix milliseconds.
+// data starts at StartLowResolution. Hourly temperatures start at Start.
+// Wind speeds (km/h) and directions (degrees) are 3-hourly values starting
+// at StartLowResolution. All timestamps are Unix milliseconds.
 type GraphData struct {
 	Start              int64     `json:"start"`
 	StartLowResolution int64     `json:"startLowResolution"`
 	Precipitation10m   []float64 `json:"precipitation10m"`
 	Precipitation1h    []float64 `json:"precipitation1h"`
+	TemperatureMean1h  []float64 `json:"temperatureMean1h,omitempty"`
+	WindSpeed3h        []float64 `json:"windSpeed3h,omitempty"`
+	GustSpeed3h        []float64 `json:"gustSpeed3h,omitempty"`
+	WindDirection3h    []int     `json:"windDirection3h,omitempty"`
 }
 
 // Warning represents a MeteoSwiss weather warning.
@@ -134,6 +140,40 @@ func IconEmoji(code int) string {
 	return "?"
 }
 
+// Icon categories returned by IconCategory.
+const (
+	CategoryClear        = "clear"
+	CategoryCloudy       = "cloudy"
+	CategoryFog          = "fog"
+	CategoryRain         = "rain"
+	CategorySnow         = "snow"
+	CategorySleet        = "sleet"
+	CategoryFreezingRain = "freezing_rain"
+	CategoryThunderstorm = "thunderstorm"
+	CategoryHail         = "hail"
+)
+
+// iconCategory groups the icon codes by the kind of weather they show.
+var iconCategory = map[int]string{
+	1: CategoryClear, 2: CategoryClear, 16: CategoryClear, 17: CategoryClear, 30: CategoryClear, 31: CategoryClear,
+	3: CategoryCloudy, 4: CategoryCloudy, 5: CategoryCloudy, 18: CategoryCloudy, 19: CategoryCloudy,
+	6: CategoryFog, 20: CategoryFog,
+	7: CategoryRain, 8: CategoryRain, 9: CategoryRain, 21: CategoryRain, 22: CategoryRain, 23: CategoryRain,
+	32: CategoryRain, 33: CategoryRain, 34: CategoryRain, 35: CategoryRain, 36: CategoryRain,
+	10: CategoryThunderstorm, 24: CategoryThunderstorm,
+	11: CategorySnow, 12: CategorySnow, 13: CategorySnow, 25: CategorySnow, 26: CategorySnow, 27: CategorySnow,
+	38: CategorySnow, 39: CategorySnow, 40: CategorySnow, 42: CategorySnow,
+	14: CategorySleet, 28: CategorySleet,
+	15: CategoryFreezingRain, 29: CategoryFreezingRain,
+	37: CategoryHail, 41: CategoryHail,
+}
+
+// IconCategory returns the weather category of an icon code, or "" for
+// unknown codes.
+func IconCategory(code int) string {
+	return iconCategory[code]
+}
+
 // WindDirection converts degrees to a cardinal direction string.
 func WindDirectionLabel(deg int) string {
 	dirs := []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
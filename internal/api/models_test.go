@@ -125,3 +125,36 @@ func TestWeatherIconCoversAll42Codes(t *testing.T) {
 		}
 	}
 }
+
+// --- IconCategory ---
+
+func TestIconCategory(t *testing.T) {
+	cases := []struct {
+		code int
+		want string
+	}{
+		{1, CategoryClear},
+		{5, CategoryCloudy},
+		{6, CategoryFog},
+		{35, CategoryRain},
+		{10, CategoryThunderstorm},
+		{39, CategorySnow},
+		{28, CategorySleet},
+		{15, CategoryFreezingRain},
+		{41, CategoryHail},
+		{0, ""},
+	}
+	for _, tc := range cases {
+		if got := IconCategory(tc.code); got != tc.want {
+			t.Errorf("IconCategory(%d) = %q, want %q", tc.code, got, tc.want)
+		}
+	}
+}
+
+func TestIconCategoryCoversAllIcons(t *testing.T) {
+	for code := range WeatherIcon {
+		if IconCategory(code) == "" {
+			t.Errorf("IconCategory(%d) is empty", code)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package api
+
+import "time"
+
+// Slot widths of the two graph resolutions.
+const (
+	HighResolution = 10 * time.Minute
+	LowResolution  = time.Hour
+)
+
+// PrecipSlot is a span of time with the precipitation accumulated over it.
+type PrecipSlot struct {
+	Start      time.Time
+	End        time.Time
+	Resolution time.Duration // width of the source slot the data came from
+	Amount     float64       // accumulated precipitation in mm
+}
+
+// Duration returns the length of the slot.
+func (s PrecipSlot) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Intensity returns the average precipitation rate over the slot in mm/h.
+func (s PrecipSlot) Intensity() float64 {
+	d := s.Duration()
+	if d <= 0 {
+		return 0
+	}
+	return s.Amount * float64(time.Hour) / float64(d)
+}
+
+// clip returns the part of s that lies within [from, to). The amount is
+// prorated on the assumption that the rate is constant within a slot.
+func (s PrecipSlot) clip(from, to time.Time) (PrecipSlot, bool) {
+	c := s
+	if c.Start.Before(from) {
+		c.Start = from
+	}
+	if c.End.After(to) {
+		c.End = to
+	}
+	if !c.End.After(c.Start) {
+		return PrecipSlot{}, false
+	}
+	if d := s.Duration(); d > 0 {
+		c.Amount = s.Amount * float64(c.Duration()) / float64(d)
+	}
+	return c, true
+}
+
+// Intensity classes returned by IntensityClass.
+const (
+	ClassNone     = "none"
+	ClassTrace    = "trace"
+	ClassLight    = "light"
+	ClassModerate = "moderate"
+	ClassHeavy    = "heavy"
+	ClassViolent  = "violent"
+)
+
+// IntensityClass names the precipitation class of a rate in mm/h, following
+// the usual meteorological bands: trace below 0.2, light below 2.5, moderate
+// below 10, heavy below 50 and violent above.
+func IntensityClass(mmh float64) string {
+	switch {
+	case mmh <= 0:
+		return ClassNone
+	case mmh < 0.2:
+		return ClassTrace
+	case mmh < 2.5:
+		return ClassLight
+	case mmh < 10:
+		return ClassModerate
+	case mmh < 50:
+		return ClassHeavy
+	default:
+		return ClassViolent
+	}
+}
+
+// PrecipSeries is a time-ordered sequence of non-overlapping slots.
+type PrecipSeries []PrecipSlot
+
+// PrecipitationSeries converts the graph data into a single series. The API
+// reports 10-minute amounts (mm per 10 min) from Start and hourly amounts
+// (mm per hour) from StartLowResolution; where the two overlap, the
+// high-resolution data wins and the hourly slot is trimmed so that no rain is
+// counted twice.
+func (g *GraphData) PrecipitationSeries() PrecipSeries {
+	if g == nil || g.Start == 0 {
+		return nil
+	}
+	var series PrecipSeries
+
+	hiStart := time.UnixMilli(g.Start)
+	hiEnd := hiStart
+	for i, p := range g.Precipitation10m {
+		start := hiStart.Add(time.Duration(i) * HighResolution)
+		hiEnd = start.Add(HighResolution)
+		series = append(series, PrecipSlot{Start: start, End: hiEnd, Resolution: HighResolution, Amount: p})
+	}
+
+	if g.StartLowResolution != 0 {
+		loStart := time.UnixMilli(g.StartLowResolution)
+		for i, p := range g.Precipitation1h {
+			start := loStart.Add(time.Duration(i) * LowResolution)
+			slot := PrecipSlot{Start: start, End: start.Add(LowResolution), Resolution: LowResolution, Amount: p}
+			if slot.Start.Before(hiEnd) {
+				var ok bool
+				if slot, ok = slot.clip(hiEnd, slot.End); !ok {
+					continue
+				}
+			}
+			series = append(series, slot)
+		}
+	}
+	return series
+}
+
+// Window returns the slots that overlap [from, to), clipped to that interval.
+func (s PrecipSeries) Window(from, to time.Time) PrecipSeries {
+	var w PrecipSeries
+	for _, slot := range s {
+		if c, ok := slot.clip(from, to); ok {
+			w = append(w, c)
+		}
+	}
+	return w
+}
+
+// In returns a copy of the series with its times in loc.
+func (s PrecipSeries) In(loc *time.Location) PrecipSeries {
+	if s == nil {
+		return nil
+	}
+	c := make(PrecipSeries, len(s))
+	for i, slot := range s {
+		slot.Start, slot.End = slot.Start.In(loc), slot.End.In(loc)
+		c[i] = slot
+	}
+	return c
+}
+
+// Total returns the precipitation accumulated over the series in mm.
+func (s PrecipSeries) Total() float64 {
+	var total float64
+	for _, slot := range s {
+		total += slot.Amount
+	}
+	return total
+}
+
+// MaxIntensity returns the highest slot intensity in mm/h and the slot it
+// occurs in. ok is false for an empty series.
+func (s PrecipSeries) MaxIntensity() (mmh float64, at PrecipSlot, ok bool) {
+	for _, slot := range s {
+		if i := slot.Intensity(); !ok || i > mmh {
+			mmh, at, ok = i, slot, true
+		}
+	}
+	return mmh, at, ok
+}
+
+// Resample redistributes the series onto consecutive steps of the given width,
+// starting at the first slot. Each step holds the prorated amounts of the
+// slots it overlaps; steps that fall into gaps in the data are omitted.
+func (s PrecipSeries) Resample(step time.Duration) PrecipSeries {
+	if len(s) == 0 || step <= 0 {
+		return nil
+	}
+	end := s[len(s)-1].End
+	var out PrecipSeries
+	for t := s[0].Start; t.Before(end); t = t.Add(step) {
+		window := s.Window(t, t.Add(step))
+		if len(window) == 0 {
+			continue
+		}
+		out = append(out, PrecipSlot{
+			Start:      window[0].Start,
+			End:        window[len(window)-1].End,
+			Resolution: step,
+			Amount:     window.Total(),
+		})
+	}
+	return out
+}
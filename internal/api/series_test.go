@@ -0,0 +1,217 @@
+package api
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var seriesStart = time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// --- PrecipSlot ---
+
+func TestPrecipSlot_intensity(t *testing.T) {
+	hi := PrecipSlot{Start: seriesStart, End: seriesStart.Add(HighResolution), Amount: 0.4}
+	if !approx(hi.Intensity(), 2.4) {
+		t.Errorf("10-min slot with 0.4 mm: Intensity() = %.3f, want 2.4", hi.Intensity())
+	}
+	lo := PrecipSlot{Start: seriesStart, End: seriesStart.Add(LowResolution), Amount: 2.4}
+	if !approx(lo.Intensity(), 2.4) {
+		t.Errorf("1-h slot with 2.4 mm: Intensity() = %.3f, want 2.4", lo.Intensity())
+	}
+}
+
+// --- IntensityClass ---
+
+func TestIntensityClass(t *testing.T) {
+	cases := []struct {
+		mmh  float64
+		want string
+	}{
+		{0, ClassNone},
+		{0.05, ClassTrace},
+		{0.2, ClassLight},
+		{2.4, ClassLight},
+		{2.5, ClassModerate},
+		{12, ClassHeavy},
+		{60, ClassViolent},
+	}
+	for _, tc := range cases {
+		if got := IntensityClass(tc.mmh); got != tc.want {
+			t.Errorf("IntensityClass(%.2f) = %q, want %q", tc.mmh, got, tc.want)
+		}
+	}
+}
+
+// --- PrecipitationSeries ---
+
+func TestPrecipitationSeries_nilAndEmpty(t *testing.T) {
+	var g *GraphData
+	if s := g.PrecipitationSeries(); s != nil {
+		t.Errorf("nil graph: series = %v, want nil", s)
+	}
+	if s := (&GraphData{}).PrecipitationSeries(); s != nil {
+		t.Errorf("zero Start: series = %v, want nil", s)
+	}
+}
+
+func TestPrecipitationSeries_resolutions(t *testing.T) {
+	g := &GraphData{
+		Start:              seriesStart.UnixMilli(),
+		StartLowResolution: seriesStart.Add(30 * time.Minute).UnixMilli(),
+		Precipitation10m:   []float64{0, 0.1, 0.2},
+		Precipitation1h:    []float64{1.2},
+	}
+	s := g.PrecipitationSeries()
+	if len(s) != 4 {
+		t.Fatalf("len(series) = %d, want 4", len(s))
+	}
+	if s[3].Resolution != LowResolution || !s[3].Start.Equal(seriesStart.Add(30*time.Minute)) {
+		t.Errorf("low-res slot = %+v, want 1h slot at 12:30", s[3])
+	}
+	if !approx(s.Total(), 1.5) {
+		t.Errorf("Total() = %.3f, want 1.5", s.Total())
+	}
+}
+
+func TestPrecipitationSeries_overlapNotDoubleCounted(t *testing.T) {
+	// Hi-res covers 12:00–12:30; the first hourly slot starts at 12:00 and
+	// must be trimmed to 12:30–13:00 with half of its amount.
+	g := &GraphData{
+		Start:              seriesStart.UnixMilli(),
+		StartLowResolution: seriesStart.UnixMilli(),
+		Precipitation10m:   []float64{0.5, 0.5, 0.5},
+		Precipitation1h:    []float64{3.0, 1.0},
+	}
+	s := g.PrecipitationSeries()
+	if len(s) != 5 {
+		t.Fatalf("len(series) = %d, want 5", len(s))
+	}
+	trimmed := s[3]
+	if !trimmed.Start.Equal(seriesStart.Add(30*time.Minute)) || !approx(trimmed.Amount, 1.5) {
+		t.Errorf("trimmed slot = %v +%v %.2f mm, want 12:30 +30m 1.5 mm", trimmed.Start, trimmed.Duration(), trimmed.Amount)
+	}
+	if !approx(trimmed.Intensity(), 3.0) {
+		t.Errorf("trimmed slot intensity = %.2f, want 3.0 (unchanged)", trimmed.Intensity())
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i].Start.Before(s[i-1].End) {
+			t.Errorf("slot %d starts at %v before slot %d ends at %v", i, s[i].Start, i-1, s[i-1].End)
+		}
+	}
+}
+
+func TestPrecipitationSeries_lowResFullyCovered(t *testing.T) {
+	g := &GraphData{
+		Start:              seriesStart.UnixMilli(),
+		StartLowResolution: seriesStart.UnixMilli(),
+		Precipitation10m:   make([]float64, 6),
+		Precipitation1h:    []float64{9.9, 1.0},
+	}
+	s := g.PrecipitationSeries()
+	if len(s) != 7 {
+		t.Fatalf("len(series) = %d, want 7 (first hourly slot dropped)", len(s))
+	}
+	if !approx(s.Total(), 1.0) {
+		t.Errorf("Total() = %.2f, want 1.0", s.Total())
+	}
+}
+
+// --- Window ---
+
+func TestPrecipSeries_windowProratesEdges(t *testing.T) {
+	g := &GraphData{
+		Start:              seriesStart.UnixMilli(),
+		StartLowResolution: seriesStart.Add(10 * time.Minute).UnixMilli(),
+		Precipitation10m:   []float64{0.6},
+		Precipitation1h:    []float64{6.0},
+	}
+	// 12:05–12:40: half of the 10-min slot and half of the hourly slot.
+	w := g.PrecipitationSeries().Window(seriesStart.Add(5*time.Minute), seriesStart.Add(40*time.Minute))
+	if len(w) != 2 {
+		t.Fatalf("len(window) = %d, want 2", len(w))
+	}
+	if !approx(w.Total(), 0.3+3.0) {
+		t.Errorf("Total() = %.3f, want 3.3", w.Total())
+	}
+}
+
+func TestPrecipSeries_windowExcludesTouchingSlot(t *testing.T) {
+	g := &GraphData{Start: seriesStart.UnixMilli(), Precipitation10m: []float64{0, 0, 0, 5}}
+	w := g.PrecipitationSeries().Window(seriesStart, seriesStart.Add(30*time.Minute))
+	if len(w) != 3 || w.Total() != 0 {
+		t.Errorf("window = %d slots, %.1f mm; want 3 dry slots", len(w), w.Total())
+	}
+}
+
+// --- In ---
+
+func TestPrecipSeries_in(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	s := PrecipSeries{{Start: seriesStart, End: seriesStart.Add(HighResolution), Amount: 0.3}}
+	got := s.In(loc)
+	if got[0].Start.Location() != loc || !got[0].Start.Equal(seriesStart) || got[0].Amount != 0.3 {
+		t.Errorf("In() = %+v, want the same slot in UTC+2", got[0])
+	}
+	if s[0].Start.Location() != time.UTC {
+		t.Error("In() modified the original series")
+	}
+}
+
+// --- MaxIntensity ---
+
+func TestPrecipSeries_maxIntensityAcrossResolutions(t *testing.T) {
+	g := &GraphData{
+		Start:              seriesStart.UnixMilli(),
+		StartLowResolution: seriesStart.Add(20 * time.Minute).UnixMilli(),
+		Precipitation10m:   []float64{0.5, 0.2}, // 3.0 and 1.2 mm/h
+		Precipitation1h:    []float64{2.0},      // 2.0 mm/h
+	}
+	mmh, at, ok := g.PrecipitationSeries().MaxIntensity()
+	if !ok || !approx(mmh, 3.0) || !at.Start.Equal(seriesStart) {
+		t.Errorf("MaxIntensity() = %.2f at %v (ok=%v), want 3.0 at 12:00", mmh, at.Start, ok)
+	}
+	if _, _, ok := PrecipSeries(nil).MaxIntensity(); ok {
+		t.Error("MaxIntensity() on empty series: ok = true, want false")
+	}
+}
+
+// --- Resample ---
+
+func TestPrecipSeries_resampleHourlyToTenMinutes(t *testing.T) {
+	g := &GraphData{
+		Start:              seriesStart.UnixMilli(),
+		StartLowResolution: seriesStart.UnixMilli(),
+		Precipitation1h:    []float64{1.2},
+	}
+	r := g.PrecipitationSeries().Resample(HighResolution)
+	if len(r) != 6 {
+		t.Fatalf("len(resampled) = %d, want 6", len(r))
+	}
+	for _, slot := range r {
+		if !approx(slot.Amount, 0.2) {
+			t.Errorf("resampled amount = %.3f, want 0.2", slot.Amount)
+		}
+	}
+}
+
+func TestPrecipSeries_resampleTenMinutesToHourly(t *testing.T) {
+	g := &GraphData{
+		Start:            seriesStart.UnixMilli(),
+		Precipitation10m: []float64{0.1, 0.2, 0.3, 0, 0, 0.4, 1.0},
+	}
+	r := g.PrecipitationSeries().Resample(LowResolution)
+	if len(r) != 2 {
+		t.Fatalf("len(resampled) = %d, want 2", len(r))
+	}
+	if !approx(r[0].Amount, 1.0) || !approx(r[1].Amount, 1.0) {
+		t.Errorf("resampled amounts = %.2f, %.2f; want 1.0, 1.0", r[0].Amount, r[1].Amount)
+	}
+	if !approx(r[0].Intensity(), 1.0) {
+		t.Errorf("hourly intensity = %.2f, want 1.0", r[0].Intensity())
+	}
+}
@@ -0,0 +1,37 @@
+package api
+
+import "time"
+
+// WindResolution is the slot width of the wind data.
+const WindResolution = 3 * time.Hour
+
+// Wind is the wind in one 3-hour slot of the graph data.
+type Wind struct {
+	Start     time.Time
+	Speed     float64 // mean speed in km/h
+	Gust      float64 // gust speed in km/h, 0 when not reported
+	Direction int     // degrees, -1 when not reported
+}
+
+// WindAt returns the wind in the slot that contains t.
+func (g *GraphData) WindAt(t time.Time) (Wind, bool) {
+	if g == nil || g.StartLowResolution == 0 || len(g.WindSpeed3h) == 0 {
+		return Wind{}, false
+	}
+	start := time.UnixMilli(g.StartLowResolution)
+	if t.Before(start) {
+		return Wind{}, false
+	}
+	i := int(t.Sub(start) / WindResolution)
+	if i >= len(g.WindSpeed3h) {
+		return Wind{}, false
+	}
+	w := Wind{Start: start.Add(time.Duration(i) * WindResolution), Speed: g.WindSpeed3h[i], Direction: -1}
+	if i < len(g.GustSpeed3h) {
+		w.Gust = g.GustSpeed3h[i]
+	}
+	if i < len(g.WindDirection3h) {
+		w.Direction = g.WindDirection3h[i]
+	}
+	return w, true
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+// --- GraphData.WindAt ---
+
+func TestGraphData_windAt(t *testing.T) {
+	g := &GraphData{
+		StartLowResolution: seriesStart.UnixMilli(),
+		WindSpeed3h:        []float64{8, 22},
+		GustSpeed3h:        []float64{15},
+		WindDirection3h:    []int{200, 250},
+	}
+	w, ok := g.WindAt(seriesStart.Add(4 * time.Hour))
+	if !ok || w.Speed != 22 || w.Gust != 0 || w.Direction != 250 || !w.Start.Equal(seriesStart.Add(3*time.Hour)) {
+		t.Errorf("WindAt(16:00) = %+v, %v", w, ok)
+	}
+	for _, at := range []time.Time{seriesStart.Add(-time.Minute), seriesStart.Add(6 * time.Hour)} {
+		if _, ok := g.WindAt(at); ok {
+			t.Errorf("WindAt(%s): want no data", at.Format("15:04"))
+		}
+	}
+	if _, ok := (*GraphData)(nil).WindAt(seriesStart); ok {
+		t.Error("WindAt on nil graph: want no data")
+	}
+}
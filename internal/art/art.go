@@ -0,0 +1,217 @@
+// Package art draws the MeteoSwiss weather icons as multi-line text art, in
+// the style of wttr.in, so that the terminal view can be read at a glance.
+//
+// Every icon code maps to one of a dozen drawings. Each drawing comes in a
+// full panel for the current conditions and a compact one for forecast days,
+// both in Unicode and in plain ASCII.
+package art
+
+import (
+	"strings"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// Size of the drawings returned by Panel and Compact, in terminal cells.
+const (
+	PanelWidth    = 13
+	PanelHeight   = 5
+	CompactWidth  = 7
+	CompactHeight = 3
+)
+
+// Drawing kinds. Several icon codes share a drawing.
+const (
+	kindSun        = "sun"
+	kindMoon       = "moon"
+	kindPartlySun  = "partly_sun"
+	kindPartlyMoon = "partly_moon"
+	kindCloud      = "cloud"
+	kindFog        = "fog"
+	kindRain       = "rain"
+	kindThunder    = "thunder"
+	kindSnow       = "snow"
+	kindSleet      = "sleet"
+	kindFreezing   = "freezing_rain"
+	kindHail       = "hail"
+	kindUnknown    = "unknown"
+)
+
+// panels are the full drawings in Unicode. Lines are padded to PanelWidth
+// when returned.
+var panels = map[string][]string{
+	kindSun: {
+		`    \   /`,
+		`     .-.`,
+		`  ― (   ) ―`,
+		"     `-’",
+		`    /   \`,
+	},
+	kindMoon: {
+		`    .--.`,
+		`   /  .'  ·`,
+		`  |  |`,
+		`   \  '.  ·`,
+		`    '--'`,
+	},
+	kindPartlySun: {
+		`   \  /`,
+		` _ /"".-.`,
+		`   \_(   ).`,
+		`   /(___(__)`,
+		``,
+	},
+	kindPartlyMoon: {
+		`    .-.   ·`,
+		`   ( (.-.`,
+		`    '(   ).`,
+		`    (___(__)`,
+		``,
+	},
+	kindCloud: {
+		``,
+		`     .--.`,
+		`  .-(    ).`,
+		` (___.__)__)`,
+		``,
+	},
+	kindFog: {
+		``,
+		` _ - _ - _ -`,
+		`  _ - _ - _`,
+		` _ - _ - _ -`,
+		``,
+	},
+	kindRain: {
+		`     .-.`,
+		`    (   ).`,
+		`   (___(__)`,
+		`    ‚‘‚‘‚‘`,
+		`    ‚’‚’‚’`,
+	},
+	kindThunder: {
+		`     .-.`,
+		`    (   ).`,
+		`   (___(__)`,
+		`    ‚‘ϟ‘‚‘`,
+		`    ‚’‚ϟ‚’`,
+	},
+	kindSnow: {
+		`     .-.`,
+		`    (   ).`,
+		`   (___(__)`,
+		`    *  *  *`,
+		`   *  *  *`,
+	},
+	kindSleet: {
+		`     .-.`,
+		`    (   ).`,
+		`   (___(__)`,
+		`    ‘ * ‘ *`,
+		`   * ‘ * ‘`,
+	},
+	kindFreezing: {
+		`     .-.`,
+		`    (   ).`,
+		`   (___(__)`,
+		`    ‚‘‚‘‚‘`,
+		`   ▁▁▁▁▁▁▁▁`,
+	},
+	kindHail: {
+		`     .-.`,
+		`    (   ).`,
+		`   (___(__)`,
+		`    o ϟ o o`,
+		`   o o ϟ o`,
+	},
+	kindUnknown: {
+		`     .-.`,
+		`      __)`,
+		`     (`,
+		"     `-’",
+		`      •`,
+	},
+}
+
+// compacts are the small drawings in Unicode, padded to CompactWidth.
+var compacts = map[string][]string{
+	kindSun:        {` \ | /`, ` ―(_)―`, ` / | \`},
+	kindMoon:       {`  .-.`, ` (  ( ·`, `  '-'`},
+	kindPartlySun:  {` \_.-.`, ` /(__)`, ``},
+	kindPartlyMoon: {` (.-.`, ` (__) ·`, ``},
+	kindCloud:      {`  .-.`, ` (___)`, ``},
+	kindFog:        {` _ - _`, ` - _ -`, ` _ - _`},
+	kindRain:       {`  .-.`, ` (___)`, ` ‚‘‚‘‚`},
+	kindThunder:    {`  .-.`, ` (___)`, ` ‚ϟ‚‘‚`},
+	kindSnow:       {`  .-.`, ` (___)`, ` * * *`},
+	kindSleet:      {`  .-.`, ` (___)`, ` ‘ * ‘`},
+	kindFreezing:   {`  .-.`, ` (___)`, ` ▁▁▁▁▁`},
+	kindHail:       {`  .-.`, ` (___)`, ` o ϟ o`},
+	kindUnknown:    {`  .-.`, `   _)`, `   •`},
+}
+
+// toASCII replaces the few non-ASCII characters of the drawings, keeping
+// their width.
+var toASCII = strings.NewReplacer(
+	"―", "-", "’", "'", "‘", "'", "‚", ",", "ϟ", "/", "▁", "_", "•", "*", "·", ".",
+)
+
+// kind returns the drawing for an icon code. Sunny spells and clear nights
+// have their own drawings; the rest follow the icon category.
+func kind(code int) string {
+	switch code {
+	case 1:
+		return kindSun
+	case 16, 17:
+		return kindMoon
+	case 2, 3, 30, 31:
+		return kindPartlySun
+	case 18:
+		return kindPartlyMoon
+	}
+	switch api.IconCategory(code) {
+	case api.CategoryClear:
+		return kindSun
+	case api.CategoryCloudy:
+		return kindCloud
+	case api.CategoryFog:
+		return kindFog
+	case api.CategoryRain:
+		return kindRain
+	case api.CategoryThunderstorm:
+		return kindThunder
+	case api.CategorySnow:
+		return kindSnow
+	case api.CategorySleet:
+		return kindSleet
+	case api.CategoryFreezingRain:
+		return kindFreezing
+	case api.CategoryHail:
+		return kindHail
+	}
+	return kindUnknown
+}
+
+// Panel returns the full drawing for an icon code: PanelHeight lines, each
+// PanelWidth cells wide. With ascii set it only uses ASCII.
+func Panel(code int, ascii bool) []string {
+	return draw(panels[kind(code)], PanelWidth, ascii)
+}
+
+// Compact returns the small drawing for an icon code: CompactHeight lines,
+// each CompactWidth cells wide. With ascii set it only uses ASCII.
+func Compact(code int, ascii bool) []string {
+	return draw(compacts[kind(code)], CompactWidth, ascii)
+}
+
+func draw(lines []string, width int, ascii bool) []string {
+	drawn := make([]string, len(lines))
+	for i, l := range lines {
+		if ascii {
+			l = toASCII.Replace(l)
+		}
+		drawn[i] = out.PadRight(l, width)
+	}
+	return drawn
+}
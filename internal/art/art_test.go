@@ -0,0 +1,66 @@
+package art
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/api"
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// --- Panel / Compact ---
+
+func TestDrawings_size(t *testing.T) {
+	for code := 0; code <= 43; code++ {
+		for _, ascii := range []bool{false, true} {
+			for _, d := range []struct {
+				name          string
+				lines         []string
+				width, height int
+			}{
+				{"Panel", Panel(code, ascii), PanelWidth, PanelHeight},
+				{"Compact", Compact(code, ascii), CompactWidth, CompactHeight},
+			} {
+				if len(d.lines) != d.height {
+					t.Errorf("%s(%d, %v): %d lines, want %d", d.name, code, ascii, len(d.lines), d.height)
+				}
+				for i, l := range d.lines {
+					if w := out.StringWidth(l); w != d.width {
+						t.Errorf("%s(%d, %v) line %d %q: width %d, want %d", d.name, code, ascii, i, l, w, d.width)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDrawings_ascii(t *testing.T) {
+	for k := range panels {
+		for _, lines := range [][]string{draw(panels[k], PanelWidth, true), draw(compacts[k], CompactWidth, true)} {
+			for _, l := range lines {
+				for _, r := range l {
+					if r > unicode.MaxASCII {
+						t.Errorf("%s: ASCII drawing has %q in %q", k, r, l)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestKind(t *testing.T) {
+	for code := range api.WeatherIcon {
+		if kind(code) == kindUnknown {
+			t.Errorf("icon %d has no drawing", code)
+		}
+	}
+	cases := map[int]string{1: kindSun, 3: kindPartlySun, 5: kindCloud, 16: kindMoon, 18: kindPartlyMoon, 35: kindRain, 41: kindHail, 99: kindUnknown}
+	for code, want := range cases {
+		if got := kind(code); got != want {
+			t.Errorf("kind(%d) = %s, want %s", code, got, want)
+		}
+	}
+	if len(panels) != len(compacts) {
+		t.Errorf("%d panels but %d compact drawings", len(panels), len(compacts))
+	}
+}
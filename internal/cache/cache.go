@@ -0,0 +1,130 @@
+// Package cache stores API responses on disk so that commands such as prompt
+// can answer from local data without waiting for the network.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Dir returns the cache directory: $XDG_CACHE_HOME/meteocli if set, else
+// meteocli under the platform's user cache directory.
+func Dir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		var err error
+		if base, err = os.UserCacheDir(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(base, "meteocli"), nil
+}
+
+// entry is the on-disk form of a cached value.
+type entry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Read decodes the value cached under key into v and returns when it was
+// fetched. A missing entry is reported as an error matching os.ErrNotExist.
+func Read(key string, v any) (time.Time, error) {
+	path, err := filename(key, ".json")
+	if err != nil {
+		return time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return time.Time{}, fmt.Errorf("cache %s: %w", key, err)
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return time.Time{}, fmt.Errorf("cache %s: %w", key, err)
+	}
+	return e.FetchedAt, nil
+}
+
+// Write stores v under key with the current time. The file is replaced
+// atomically so that concurrent readers never see a partial entry.
+func Write(key string, v any) error {
+	path, err := filename(key, ".json")
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(entry{FetchedAt: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Lock takes an advisory lock on key, e.g. to let only one background
+// refresh run at a time. A lock older than ttl is considered abandoned and
+// taken over. ok is false if someone else holds the lock.
+func Lock(key string, ttl time.Duration) (release func(), ok bool) {
+	path, err := filename(key, ".lock")
+	if err != nil {
+		return nil, false
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, false
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, true
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, false
+		}
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < ttl {
+			return nil, false
+		}
+		os.Remove(path)
+	}
+	return nil, false
+}
+
+// Unlock removes the lock on key regardless of who took it.
+func Unlock(key string) {
+	if path, err := filename(key, ".lock"); err == nil {
+		os.Remove(path)
+	}
+}
+
+func filename(key, ext string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, key+ext), nil
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// --- Dir ---
+
+func TestDir_xdg(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+	dir, err := Dir()
+	if err != nil || dir != filepath.Join("/tmp/xdg-cache", "meteocli") {
+		t.Errorf("Dir() = %q, %v; want /tmp/xdg-cache/meteocli", dir, err)
+	}
+}
+
+// --- Read / Write ---
+
+func TestReadWrite_roundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	type payload struct{ Temp float64 }
+	before := time.Now()
+	if err := Write("plz-8000", payload{Temp: 14.2}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	var got payload
+	at, err := Read("plz-8000", &got)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if got.Temp != 14.2 {
+		t.Errorf("Read() = %+v, want Temp 14.2", got)
+	}
+	if at.Before(before.Add(-time.Second)) || at.After(time.Now()) {
+		t.Errorf("fetched at %v, want about now", at)
+	}
+}
+
+func TestRead_missing(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	var v any
+	if _, err := Read("plz-3000", &v); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Read() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestRead_corrupt(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	_ = os.MkdirAll(filepath.Join(dir, "meteocli"), 0o755)
+	_ = os.WriteFile(filepath.Join(dir, "meteocli", "plz-8000.json"), []byte("{"), 0o644)
+	var v any
+	if _, err := Read("plz-8000", &v); err == nil || errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Read() error = %v, want a decode error", err)
+	}
+}
+
+// --- Lock ---
+
+func TestLock_exclusive(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	release, ok := Lock("refresh", time.Minute)
+	if !ok {
+		t.Fatal("first Lock() failed")
+	}
+	if _, ok := Lock("refresh", time.Minute); ok {
+		t.Error("second Lock() succeeded while the first is held")
+	}
+	release()
+	if _, ok := Lock("refresh", time.Minute); !ok {
+		t.Error("Lock() after release failed")
+	}
+}
+
+func TestLock_takesOverAbandonedLock(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	if _, ok := Lock("refresh", time.Minute); !ok {
+		t.Fatal("first Lock() failed")
+	}
+	if _, ok := Lock("refresh", 0); !ok {
+		t.Error("Lock() with zero ttl did not take over the stale lock")
+	}
+}
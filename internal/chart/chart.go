@@ -0,0 +1,96 @@
+// Package chart draws small text charts for the terminal: sparklines of a
+// series of values, bands showing a range on a scale, and axes with labels
+// at given columns. Every chart comes in Unicode and in plain ASCII.
+package chart
+
+import (
+	"math"
+	"strings"
+
+	"github.com/a-fgx/meteoswiss-cli/internal/out"
+)
+
+// Glyphs of the sparkline levels, from the lowest non-zero level to the
+// highest. Zero is drawn as a space.
+var (
+	sparkUnicode = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	sparkASCII   = []string{".", ":", "-", "=", "+", "*", "#", "@"}
+)
+
+// Full returns the glyph of the highest sparkline level, for legends.
+func Full(ascii bool) string {
+	if ascii {
+		return sparkASCII[len(sparkASCII)-1]
+	}
+	return sparkUnicode[len(sparkUnicode)-1]
+}
+
+// Sparkline draws one cell per value, scaled so that top fills a cell.
+// Values of zero or less are blank and any value above zero shows at least
+// the lowest level, so that a trace of rain remains visible.
+func Sparkline(values []float64, top float64, ascii bool) string {
+	glyphs := sparkUnicode
+	if ascii {
+		glyphs = sparkASCII
+	}
+	var b strings.Builder
+	for _, v := range values {
+		if v <= 0 || top <= 0 {
+			b.WriteByte(' ')
+			continue
+		}
+		level := int(math.Ceil(v / top * float64(len(glyphs))))
+		b.WriteString(glyphs[clamp(level, 1, len(glyphs))-1])
+	}
+	return b.String()
+}
+
+// Band draws the range lo to hi on a scale from scaleMin to scaleMax that is
+// width cells wide, e.g. "····█████·······".
+func Band(lo, hi, scaleMin, scaleMax float64, width int, ascii bool) string {
+	fill, empty := "█", "·"
+	if ascii {
+		fill, empty = "#", "."
+	}
+	if width <= 0 {
+		return ""
+	}
+	col := func(v float64) int {
+		if scaleMax <= scaleMin {
+			return 0
+		}
+		c := int(math.Round((v - scaleMin) / (scaleMax - scaleMin) * float64(width-1)))
+		return clamp(c, 0, width-1)
+	}
+	from, to := col(lo), col(hi)
+	if to < from {
+		from, to = to, from
+	}
+	return strings.Repeat(empty, from) + strings.Repeat(fill, to-from+1) + strings.Repeat(empty, width-1-to)
+}
+
+// Mark is a label at a column of a chart.
+type Mark struct {
+	Col   int
+	Label string
+}
+
+// Axis draws the labels of marks, each starting at its column. Marks must be
+// ordered by column; a label that would touch the previous one is left out.
+func Axis(marks []Mark) string {
+	var b strings.Builder
+	n := 0
+	for _, m := range marks {
+		if m.Col < n || (n > 0 && m.Col == n) {
+			continue
+		}
+		b.WriteString(strings.Repeat(" ", m.Col-n))
+		b.WriteString(m.Label)
+		n = m.Col + out.StringWidth(m.Label)
+	}
+	return b.String()
+}
+
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
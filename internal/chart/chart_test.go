@@ -0,0 +1,59 @@
+package chart
+
+import "testing"
+
+// --- Sparkline ---
+
+func TestSparkline(t *testing.T) {
+	values := []float64{0, 0.01, 1, 2, 4, 8, 12}
+	if got, want := Sparkline(values, 8, false), " ▁▁▂▄██"; got != want {
+		t.Errorf("Sparkline() = %q, want %q", got, want)
+	}
+	if got, want := Sparkline(values, 8, true), " ..:=@@"; got != want {
+		t.Errorf("Sparkline(ascii) = %q, want %q", got, want)
+	}
+	if got := Sparkline([]float64{0, 1}, 0, false); got != "  " {
+		t.Errorf("Sparkline(top 0) = %q, want blanks", got)
+	}
+}
+
+// --- Band ---
+
+func TestBand(t *testing.T) {
+	cases := []struct {
+		lo, hi float64
+		want   string
+	}{
+		{0, 10, "███████████"},
+		{2, 5, "··████·····"},
+		{5, 2, "··████·····"},
+		{-5, 3, "████·······"},
+		{7, 7, "·······█···"},
+	}
+	for _, tc := range cases {
+		if got := Band(tc.lo, tc.hi, 0, 10, 11, false); got != tc.want {
+			t.Errorf("Band(%v, %v) = %q, want %q", tc.lo, tc.hi, got, tc.want)
+		}
+	}
+	if got, want := Band(2, 5, 0, 10, 11, true), "..####....."; got != want {
+		t.Errorf("Band(ascii) = %q, want %q", got, want)
+	}
+	if got := Band(1, 2, 3, 3, 4, false); got != "█···" {
+		t.Errorf("Band(empty scale) = %q, want %q", got, "█···")
+	}
+}
+
+// --- Axis ---
+
+func TestAxis(t *testing.T) {
+	got := Axis([]Mark{{0, "13:00"}, {6, "14:00"}, {9, "x"}, {12, "15:00"}})
+	if want := "13:00 14:00 15:00"; got != want {
+		t.Errorf("Axis() = %q, want %q", got, want)
+	}
+	if got := Axis([]Mark{{3, "▲ now"}}); got != "   ▲ now" {
+		t.Errorf("Axis() = %q", got)
+	}
+	if got := Axis([]Mark{{0, "ab"}, {2, "cd"}}); got != "ab" {
+		t.Errorf("Axis(touching) = %q, want the second label left out", got)
+	}
+}
@@ -0,0 +1,83 @@
+// Package config reads the user's meteocli configuration file.
+//
+// The file is JSON and lives at $XDG_CONFIG_HOME/meteocli/config.json (or
+// meteocli/config.json under the platform's user config directory):
+//
+//	{
+//	  "locations": {"home": 8000, "work": 3011},
+//	  "theme": "high-contrast"
+//	}
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Config is the contents of the configuration file.
+type Config struct {
+	// Locations maps names such as "home" to Swiss postal codes.
+	Locations map[string]int `json:"locations,omitempty"`
+
+	// Theme names the colour theme of text output.
+	Theme string `json:"theme,omitempty"`
+}
+
+// Path returns the location of the configuration file.
+func Path() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		var err error
+		if base, err = os.UserConfigDir(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(base, "meteocli", "config.json"), nil
+}
+
+// Load reads the configuration file. A missing file yields an empty
+// configuration.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Resolve turns a location given on the command line into a postal code.
+// loc is either a postal code or the name of a configured location.
+func (c *Config) Resolve(loc string) (int, error) {
+	if plz, err := strconv.Atoi(loc); err == nil {
+		return plz, nil
+	}
+	if plz, ok := c.Locations[loc]; ok {
+		return plz, nil
+	}
+	if len(c.Locations) == 0 {
+		return 0, fmt.Errorf("unknown location %q: no locations configured", loc)
+	}
+	names := make([]string, 0, len(c.Locations))
+	for name := range c.Locations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return 0, fmt.Errorf("unknown location %q: want a postal code or one of %s", loc, strings.Join(names, ", "))
+}
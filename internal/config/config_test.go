@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "meteocli"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "meteocli", "config.json"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// --- Load ---
+
+func TestLoad_missingFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c, err := Load()
+	if err != nil || len(c.Locations) != 0 {
+		t.Errorf("Load() = %+v, %v; want empty config", c, err)
+	}
+}
+
+func TestLoad_locations(t *testing.T) {
+	writeConfig(t, `{"locations": {"home": 8000, "work": 3011}}`)
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if c.Locations["home"] != 8000 || c.Locations["work"] != 3011 {
+		t.Errorf("Locations = %v", c.Locations)
+	}
+}
+
+func TestLoad_theme(t *testing.T) {
+	writeConfig(t, `{"theme": "high-contrast"}`)
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if c.Theme != "high-contrast" {
+		t.Errorf("Theme = %q, want high-contrast", c.Theme)
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	writeConfig(t, `{"locations": [}`)
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "config.json") {
+		t.Errorf("Load() error = %v, want a decode error naming the file", err)
+	}
+}
+
+// --- Resolve ---
+
+func TestResolve(t *testing.T) {
+	c := &Config{Locations: map[string]int{"home": 8000, "work": 3011}}
+	if plz, err := c.Resolve("home"); err != nil || plz != 8000 {
+		t.Errorf("Resolve(home) = %d, %v; want 8000", plz, err)
+	}
+	if plz, err := c.Resolve("1200"); err != nil || plz != 1200 {
+		t.Errorf("Resolve(1200) = %d, %v; want 1200", plz, err)
+	}
+	_, err := c.Resolve("cabin")
+	if err == nil || !strings.Contains(err.Error(), "home, work") {
+		t.Errorf("Resolve(cabin) error = %v, want the known names listed", err)
+	}
+	if _, err := (&Config{}).Resolve("home"); err == nil {
+		t.Error("Resolve() without locations: expected error, got nil")
+	}
+}
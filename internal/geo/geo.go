@@ -0,0 +1,147 @@
+// Package geo maps coordinates to Swiss postal localities.
+//
+// The built-in gazetteer is deliberately coarse: it lists one reference point
+// per locality for the larger towns and the common alpine bases, which is
+// enough to pick a forecast location along a hiking or cycling route.
+package geo
+
+import "math"
+
+// earthRadiusKM is the mean Earth radius used for great-circle distances.
+const earthRadiusKM = 6371.0
+
+// Locality is a Swiss postal locality with a representative coordinate.
+type Locality struct {
+	PLZ  int     `json:"plz"`
+	Name string  `json:"name"`
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+}
+
+// Localities is the built-in gazetteer, ordered by postal code.
+var Localities = []Locality{
+	{1000, "Lausanne", 46.5197, 6.6323},
+	{1200, "Genève", 46.2044, 6.1432},
+	{1260, "Nyon", 46.3833, 6.2396},
+	{1400, "Yverdon-les-Bains", 46.7785, 6.6411},
+	{1630, "Bulle", 46.6193, 7.0570},
+	{1700, "Fribourg", 46.8065, 7.1620},
+	{1800, "Vevey", 46.4628, 6.8419},
+	{1820, "Montreux", 46.4312, 6.9107},
+	{1854, "Leysin", 46.3417, 7.0122},
+	{1870, "Monthey", 46.2546, 6.9544},
+	{1884, "Villars-sur-Ollon", 46.2985, 7.0553},
+	{1920, "Martigny", 46.1028, 7.0724},
+	{1936, "Verbier", 46.0961, 7.2286},
+	{1950, "Sion", 46.2331, 7.3606},
+	{2000, "Neuchâtel", 46.9900, 6.9293},
+	{2300, "La Chaux-de-Fonds", 47.1035, 6.8328},
+	{2500, "Biel/Bienne", 47.1368, 7.2468},
+	{2800, "Delémont", 47.3649, 7.3445},
+	{3000, "Bern", 46.9480, 7.4474},
+	{3280, "Murten", 46.9282, 7.1177},
+	{3400, "Burgdorf", 47.0559, 7.6273},
+	{3600, "Thun", 46.7580, 7.6280},
+	{3700, "Spiez", 46.6863, 7.6808},
+	{3718, "Kandersteg", 46.4948, 7.6745},
+	{3780, "Gstaad", 46.4750, 7.2861},
+	{3800, "Interlaken", 46.6863, 7.8632},
+	{3818, "Grindelwald", 46.6242, 8.0414},
+	{3822, "Lauterbrunnen", 46.5935, 7.9091},
+	{3823, "Wengen", 46.6083, 7.9219},
+	{3825, "Mürren", 46.5590, 7.8924},
+	{3860, "Meiringen", 46.7271, 8.1872},
+	{3900, "Brig", 46.3159, 7.9877},
+	{3906, "Saas-Fee", 46.1081, 7.9276},
+	{3920, "Zermatt", 46.0207, 7.7491},
+	{3930, "Visp", 46.2937, 7.8815},
+	{3954, "Leukerbad", 46.3792, 7.6270},
+	{3963, "Crans-Montana", 46.3103, 7.4802},
+	{3984, "Fiesch", 46.4004, 8.1357},
+	{3999, "Oberwald", 46.5336, 8.3490},
+	{4000, "Basel", 47.5596, 7.5886},
+	{4410, "Liestal", 47.4846, 7.7346},
+	{4500, "Solothurn", 47.2088, 7.5323},
+	{4600, "Olten", 47.3498, 7.9033},
+	{5000, "Aarau", 47.3925, 8.0444},
+	{5400, "Baden", 47.4733, 8.3059},
+	{6000, "Luzern", 47.0502, 8.3093},
+	{6300, "Zug", 47.1662, 8.5155},
+	{6370, "Stans", 46.9580, 8.3660},
+	{6390, "Engelberg", 46.8196, 8.4076},
+	{6410, "Goldau", 47.0476, 8.5484},
+	{6430, "Schwyz", 47.0207, 8.6530},
+	{6460, "Altdorf", 46.8806, 8.6444},
+	{6490, "Andermatt", 46.6356, 8.5939},
+	{6500, "Bellinzona", 46.1946, 9.0244},
+	{6600, "Locarno", 46.1709, 8.7995},
+	{6780, "Airolo", 46.5286, 8.6102},
+	{6850, "Mendrisio", 45.8702, 8.9817},
+	{6900, "Lugano", 46.0037, 8.9511},
+	{7000, "Chur", 46.8508, 9.5320},
+	{7050, "Arosa", 46.7784, 9.6795},
+	{7130, "Ilanz", 46.7739, 9.2047},
+	{7180, "Disentis/Mustér", 46.7046, 8.8534},
+	{7250, "Klosters", 46.8693, 9.8801},
+	{7270, "Davos", 46.7963, 9.8200},
+	{7310, "Bad Ragaz", 47.0068, 9.5030},
+	{7500, "St. Moritz", 46.4908, 9.8355},
+	{7504, "Pontresina", 46.4956, 9.9010},
+	{7550, "Scuol", 46.7963, 10.2977},
+	{7742, "Poschiavo", 46.3245, 10.0583},
+	{8000, "Zürich", 47.3769, 8.5417},
+	{8180, "Bülach", 47.5220, 8.5400},
+	{8200, "Schaffhausen", 47.6973, 8.6349},
+	{8280, "Kreuzlingen", 47.6506, 9.1750},
+	{8400, "Winterthur", 47.4988, 8.7237},
+	{8500, "Frauenfeld", 47.5536, 8.8987},
+	{8580, "Amriswil", 47.5469, 9.2981},
+	{8600, "Dübendorf", 47.3972, 8.6183},
+	{8640, "Rapperswil", 47.2266, 8.8184},
+	{8750, "Glarus", 47.0404, 9.0679},
+	{8784, "Braunwald", 46.9406, 8.9983},
+	{8810, "Horgen", 47.2596, 8.5977},
+	{8840, "Einsiedeln", 47.1285, 8.7467},
+	{8880, "Walenstadt", 47.1239, 9.3120},
+	{9000, "St. Gallen", 47.4245, 9.3767},
+	{9050, "Appenzell", 47.3306, 9.4086},
+	{9100, "Herisau", 47.3861, 9.2792},
+	{9470, "Buchs SG", 47.1661, 9.4717},
+	{9500, "Wil", 47.4615, 9.0400},
+	{9658, "Wildhaus", 47.2043, 9.3528},
+}
+
+// Nearest returns the gazetteer locality closest to (lat, lon) and its
+// great-circle distance in kilometres.
+func Nearest(lat, lon float64) (Locality, float64) {
+	best := Localities[0]
+	bestKM := DistanceKM(lat, lon, best.Lat, best.Lon)
+	for _, l := range Localities[1:] {
+		if d := DistanceKM(lat, lon, l.Lat, l.Lon); d < bestKM {
+			best, bestKM = l, d
+		}
+	}
+	return best, bestKM
+}
+
+// ByPLZ looks up a locality by its postal code.
+func ByPLZ(plz int) (Locality, bool) {
+	for _, l := range Localities {
+		if l.PLZ == plz {
+			return l, true
+		}
+	}
+	return Locality{}, false
+}
+
+// DistanceKM returns the great-circle (haversine) distance in kilometres
+// between two WGS84 coordinates given in degrees.
+func DistanceKM(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKM * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,70 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// --- DistanceKM ---
+
+func TestDistanceKM_samePoint(t *testing.T) {
+	if d := DistanceKM(46.9480, 7.4474, 46.9480, 7.4474); d != 0 {
+		t.Errorf("DistanceKM(same point) = %.3f, want 0", d)
+	}
+}
+
+func TestDistanceKM_bernZurich(t *testing.T) {
+	// Bern → Zürich is roughly 95 km as the crow flies.
+	d := DistanceKM(46.9480, 7.4474, 47.3769, 8.5417)
+	if math.Abs(d-95) > 3 {
+		t.Errorf("DistanceKM(Bern, Zürich) = %.1f km, want ≈95", d)
+	}
+}
+
+// --- Nearest ---
+
+func TestNearest_exactLocality(t *testing.T) {
+	loc, km := Nearest(46.0207, 7.7491)
+	if loc.PLZ != 3920 {
+		t.Errorf("Nearest(Zermatt) PLZ = %d, want 3920", loc.PLZ)
+	}
+	if km > 0.01 {
+		t.Errorf("Nearest(Zermatt) distance = %.3f km, want ≈0", km)
+	}
+}
+
+func TestNearest_betweenLocalities(t *testing.T) {
+	// Kleine Scheidegg lies between Grindelwald and Wengen, closer to Wengen.
+	loc, _ := Nearest(46.5853, 7.9614)
+	if loc.PLZ != 3823 && loc.PLZ != 3818 {
+		t.Errorf("Nearest(Kleine Scheidegg) PLZ = %d, want 3823 or 3818", loc.PLZ)
+	}
+}
+
+// --- ByPLZ ---
+
+func TestByPLZ(t *testing.T) {
+	loc, ok := ByPLZ(3000)
+	if !ok || loc.Name != "Bern" {
+		t.Errorf("ByPLZ(3000) = %+v, %v; want Bern", loc, ok)
+	}
+	if _, ok := ByPLZ(1234); ok {
+		t.Error("ByPLZ(1234) ok = true, want false")
+	}
+}
+
+// --- Localities ---
+
+func TestLocalities_sortedAndValid(t *testing.T) {
+	for i, l := range Localities {
+		if l.PLZ < 1000 || l.PLZ > 9999 {
+			t.Errorf("locality %q has invalid PLZ %d", l.Name, l.PLZ)
+		}
+		if l.Lat < 45.8 || l.Lat > 47.9 || l.Lon < 5.9 || l.Lon > 10.5 {
+			t.Errorf("locality %q (%d) lies outside Switzerland: %.4f, %.4f", l.Name, l.PLZ, l.Lat, l.Lon)
+		}
+		if i > 0 && Localities[i-1].PLZ >= l.PLZ {
+			t.Errorf("Localities not sorted at %d (%d after %d)", i, l.PLZ, Localities[i-1].PLZ)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package gpx reads track and route points from GPX 1.0/1.1 files.
+package gpx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Point is a single WGS84 track or route point.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+	Ele float64 `json:"ele"`
+}
+
+// document mirrors the subset of the GPX schema we care about. Element names
+// are matched regardless of the GPX namespace version.
+type document struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []point `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Points []point `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+type point struct {
+	Lat float64 `xml:"lat,attr"`
+	Lon float64 `xml:"lon,attr"`
+	Ele float64 `xml:"ele"`
+}
+
+// Parse decodes a GPX document and returns its points in file order. Track
+// points from all tracks and segments are concatenated; route points are only
+// used when the file contains no tracks.
+func Parse(r io.Reader) ([]Point, error) {
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding GPX: %w", err)
+	}
+
+	var pts []Point
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				pts = append(pts, Point(p))
+			}
+		}
+	}
+	if len(pts) == 0 {
+		for _, rte := range doc.Routes {
+			for _, p := range rte.Points {
+				pts = append(pts, Point(p))
+			}
+		}
+	}
+	if len(pts) == 0 {
+		return nil, errors.New("GPX contains no track or route points")
+	}
+	return pts, nil
+}
+
+// ParseFile opens path and parses it with Parse.
+func ParseFile(path string) ([]Point, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pts, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return pts, nil
+}
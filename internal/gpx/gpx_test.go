@@ -0,0 +1,83 @@
+package gpx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const trackGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+    <name>Eiger Trail</name>
+    <trkseg>
+      <trkpt lat="46.5853" lon="7.9614"><ele>2061</ele></trkpt>
+      <trkpt lat="46.5900" lon="7.9900"><ele>1900</ele></trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="46.6242" lon="8.0414"><ele>1034</ele></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+// --- Parse ---
+
+func TestParse_trackSegmentsConcatenated(t *testing.T) {
+	pts, err := Parse(strings.NewReader(trackGPX))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if len(pts) != 3 {
+		t.Fatalf("len(points) = %d, want 3", len(pts))
+	}
+	if pts[0].Lat != 46.5853 || pts[0].Lon != 7.9614 || pts[0].Ele != 2061 {
+		t.Errorf("first point = %+v, want {46.5853 7.9614 2061}", pts[0])
+	}
+	if pts[2].Lon != 8.0414 {
+		t.Errorf("last point lon = %.4f, want 8.0414", pts[2].Lon)
+	}
+}
+
+func TestParse_routeFallback(t *testing.T) {
+	const body = `<gpx version="1.0"><rte>
+		<rtept lat="47.0" lon="8.0"/><rtept lat="47.1" lon="8.1"/>
+	</rte></gpx>`
+	pts, err := Parse(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if len(pts) != 2 {
+		t.Errorf("len(points) = %d, want 2", len(pts))
+	}
+}
+
+func TestParse_noPoints(t *testing.T) {
+	_, err := Parse(strings.NewReader(`<gpx version="1.1"><trk><trkseg/></trk></gpx>`))
+	if err == nil {
+		t.Error("expected error for GPX without points, got nil")
+	}
+}
+
+func TestParse_badXML(t *testing.T) {
+	_, err := Parse(strings.NewReader(`<gpx><trk>`))
+	if err == nil {
+		t.Error("expected error for truncated XML, got nil")
+	}
+}
+
+// --- ParseFile ---
+
+func TestParseFile_errorMentionsPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.gpx")
+	if err := os.WriteFile(path, []byte(`<gpx/>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := ParseFile(path)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "broken.gpx") {
+		t.Errorf("error %q should mention the file name", err.Error())
+	}
+}
@@ -0,0 +1,156 @@
+package out
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Values of --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ColorEnabled decides whether text written to w is coloured: always for
+// "always", never for "never", and for "auto" only when w is a terminal,
+// NO_COLOR is unset or empty and TERM is not "dumb".
+func ColorEnabled(mode string, w io.Writer) (bool, error) {
+	switch mode {
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	case ColorAuto, "":
+	default:
+		return false, fmt.Errorf("invalid --color %q: want %s, %s or %s", mode, ColorAuto, ColorAlways, ColorNever)
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false, nil
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false, nil
+	}
+	_, isTerminal := terminalWidth(f)
+	return isTerminal, nil
+}
+
+// TempStop is a step of a temperature gradient: temperatures below Below
+// (°C) are drawn with the SGR parameters SGR.
+type TempStop struct {
+	Below float64
+	SGR   string
+}
+
+// Theme is a set of colours, given as ANSI SGR parameters such as "1;31".
+type Theme struct {
+	// Temps is the temperature gradient, ordered by Below. Temperatures
+	// above the last stop use Hot.
+	Temps []TempStop
+	Hot   string
+	// Levels colours warnings by level, from 1 (minor) to 5 (very high).
+	Levels [5]string
+	// Rain highlights precipitation above a threshold.
+	Rain string
+}
+
+// Themes are the built-in themes by name. Warning levels follow the colours
+// of the official scale: yellow, orange, red and purple for levels 2 to 5.
+var Themes = map[string]Theme{
+	"default": {
+		Temps: []TempStop{
+			{-10, "38;5;21"}, {-5, "38;5;27"}, {0, "38;5;39"}, {5, "38;5;45"},
+			{10, "38;5;49"}, {15, "38;5;118"}, {20, "38;5;226"}, {25, "38;5;214"}, {30, "38;5;202"},
+		},
+		Hot:    "38;5;196",
+		Levels: [5]string{"38;5;40", "38;5;226", "38;5;208", "38;5;196", "38;5;129"},
+		Rain:   "38;5;33",
+	},
+	// high-contrast uses bold text and backgrounds from the 16 basic colours,
+	// which terminal colour schemes keep distinguishable.
+	"high-contrast": {
+		Temps:  []TempStop{{0, "1;96"}, {10, "1;97"}, {25, "1;93"}},
+		Hot:    "1;91",
+		Levels: [5]string{"1;30;42", "1;30;43", "1;30;48;5;208", "1;97;41", "1;97;45"},
+		Rain:   "1;4;96",
+	},
+}
+
+// DefaultTheme is the theme used when the configuration names none.
+const DefaultTheme = "default"
+
+// LookupTheme returns the built-in theme called name.
+func LookupTheme(name string) (Theme, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	if t, ok := Themes[name]; ok {
+		return t, nil
+	}
+	names := make([]string, 0, len(Themes))
+	for n := range Themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return Theme{}, fmt.Errorf("unknown theme %q: want one of %s", name, strings.Join(names, ", "))
+}
+
+// Palette colours text with a theme. The zero Palette leaves text as is, so
+// printers can use it unconditionally.
+type Palette struct {
+	theme *Theme
+}
+
+// NewPalette returns a palette for theme, or the zero Palette if colour is
+// disabled.
+func NewPalette(theme Theme, enabled bool) Palette {
+	if !enabled {
+		return Palette{}
+	}
+	return Palette{theme: &theme}
+}
+
+// Enabled reports whether the palette colours text.
+func (p Palette) Enabled() bool {
+	return p.theme != nil
+}
+
+// Temp colours s by the temperature c in °C.
+func (p Palette) Temp(s string, c float64) string {
+	if p.theme == nil {
+		return s
+	}
+	for _, stop := range p.theme.Temps {
+		if c < stop.Below {
+			return paint(s, stop.SGR)
+		}
+	}
+	return paint(s, p.theme.Hot)
+}
+
+// Level colours s by the warning level (1–5).
+func (p Palette) Level(s string, level int) string {
+	if p.theme == nil || level < 1 || level > len(p.theme.Levels) {
+		return s
+	}
+	return paint(s, p.theme.Levels[level-1])
+}
+
+// Rain highlights s when wet is true.
+func (p Palette) Rain(s string, wet bool) string {
+	if p.theme == nil || !wet {
+		return s
+	}
+	return paint(s, p.theme.Rain)
+}
+
+func paint(s, sgr string) string {
+	if sgr == "" || s == "" {
+		return s
+	}
+	return "\x1b[" + sgr + "m" + s + "\x1b[0m"
+}
@@ -0,0 +1,88 @@
+package out
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// --- ColorEnabled ---
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	var buf bytes.Buffer
+	cases := map[string]bool{ColorAuto: false, ColorAlways: true, ColorNever: false}
+	for mode, want := range cases {
+		got, err := ColorEnabled(mode, &buf)
+		if err != nil || got != want {
+			t.Errorf("ColorEnabled(%q, buffer) = %v, %v; want %v", mode, got, err, want)
+		}
+	}
+	if _, err := ColorEnabled("sometimes", &buf); err == nil {
+		t.Error("expected error for unknown mode, got nil")
+	}
+}
+
+func TestColorEnabled_noColorLosesToAlways(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if got, _ := ColorEnabled(ColorAlways, &bytes.Buffer{}); !got {
+		t.Error("--color always should win over NO_COLOR")
+	}
+}
+
+// --- Palette ---
+
+func TestPalette_zeroLeavesTextAlone(t *testing.T) {
+	var p Palette
+	if p.Temp("14", 14) != "14" || p.Level("High", 4) != "High" || p.Rain("5.0", true) != "5.0" {
+		t.Error("zero Palette changed the text")
+	}
+}
+
+func TestPalette_colours(t *testing.T) {
+	theme, err := LookupTheme("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPalette(theme, true)
+	if got := p.Temp("-12", -12); got != "\x1b[38;5;21m-12\x1b[0m" {
+		t.Errorf("Temp(-12) = %q", got)
+	}
+	if got := p.Temp("40", 40); got != "\x1b[38;5;196m40\x1b[0m" {
+		t.Errorf("Temp(40) = %q", got)
+	}
+	if got := p.Level("Very high", 5); got != "\x1b[38;5;129mVery high\x1b[0m" {
+		t.Errorf("Level(5) = %q", got)
+	}
+	if got := p.Rain("0.2", false); got != "0.2" {
+		t.Errorf("Rain(dry) = %q, want no colour", got)
+	}
+	if got := p.Level("?", 9); got != "?" {
+		t.Errorf("Level(9) = %q, want no colour", got)
+	}
+}
+
+func TestLookupTheme(t *testing.T) {
+	if _, err := LookupTheme("high-contrast"); err != nil {
+		t.Errorf("high-contrast: %v", err)
+	}
+	if _, err := LookupTheme("neon"); err == nil || !strings.Contains(err.Error(), "high-contrast") {
+		t.Errorf("LookupTheme(neon) error = %v, want the themes listed", err)
+	}
+}
+
+// --- escape sequences and width ---
+
+func TestStringWidth_ignoresEscapes(t *testing.T) {
+	if got := StringWidth("\x1b[38;5;196m14.0\x1b[0m"); got != 4 {
+		t.Errorf("StringWidth(coloured) = %d, want 4", got)
+	}
+}
+
+func TestTruncate_resetsColour(t *testing.T) {
+	got := Truncate("\x1b[1;31mThunderstorm\x1b[0m", 6)
+	if got != "\x1b[1;31mThund…\x1b[0m" {
+		t.Errorf("Truncate(coloured) = %q", got)
+	}
+}
@@ -1,22 +1,22 @@
-// Package out provides helpers for writing human-readable or JSON output to
-// an io.Writer.
+// Package out provides helpers for writing command results to an io.Writer,
+// either as human-readable text or in one of the structured formats selected
+// with NewRenderer.
 package out
 
 import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 )
 
-// Print writes a human-readable message to stdout.
-func Print(format string, args ...any) {
-	fmt.Fprintf(os.Stdout, format, args...)
+// Print writes a human-readable message to w.
+func Print(w io.Writer, format string, args ...any) {
+	fmt.Fprintf(w, format, args...)
 }
 
-// Println writes a human-readable line to stdout.
-func Println(s string) {
-	fmt.Fprintln(os.Stdout, s)
+// Println writes a human-readable line to w.
+func Println(w io.Writer, s string) {
+	fmt.Fprintln(w, s)
 }
 
 // PrintJSON marshals v to indented JSON and writes it to w.
@@ -35,10 +35,10 @@ func WriteError(w io.Writer, asJSON bool, err error) error {
 	return nil
 }
 
-// Sep prints a separator line of n dashes to stdout.
-func Sep(n int) {
+// Sep prints a separator line of n dashes to w.
+func Sep(w io.Writer, n int) {
 	for i := 0; i < n; i++ {
-		fmt.Fprint(os.Stdout, "─")
+		fmt.Fprint(w, "─")
 	}
-	fmt.Fprintln(os.Stdout)
+	fmt.Fprintln(w)
 }
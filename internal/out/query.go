@@ -0,0 +1,504 @@
+package out
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SelectFields keeps only the given fields of each record in v: of every
+// element of a list, or of v itself when it is an object. Fields may name
+// nested values with dots (e.g. "slot.start") and appear in the order given.
+// A field found in no record is an error, so that typos do not silently
+// produce empty columns.
+func SelectFields(v any, fields []string) (any, error) {
+	t, err := Tree(v)
+	if err != nil {
+		return nil, err
+	}
+	list, isList := t.([]any)
+	if !isList {
+		list = []any{t}
+	}
+
+	found := make([]bool, len(fields))
+	selected := make([]any, len(list))
+	for i, item := range list {
+		rec, ok := item.(Object)
+		if !ok {
+			return nil, fmt.Errorf("--fields: the output is not made of objects")
+		}
+		sel := Object{}
+		for j, f := range fields {
+			val, ok := lookupPath(rec, f)
+			found[j] = found[j] || ok
+			sel = append(sel, Member{Key: f, Value: val})
+		}
+		selected[i] = sel
+	}
+	for j, f := range fields {
+		if !found[j] && len(list) > 0 {
+			return nil, fmt.Errorf("--fields: unknown field %q: want one of %s", f, strings.Join(fieldNames(list[0]), ", "))
+		}
+	}
+
+	if !isList {
+		return selected[0], nil
+	}
+	return selected, nil
+}
+
+// lookupPath returns the value at a dotted path in obj.
+func lookupPath(obj Object, path string) (any, bool) {
+	var cur any = obj
+	for _, key := range strings.Split(path, ".") {
+		o, ok := cur.(Object)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = o.Get(key); !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// fieldNames lists the dotted names of the scalar fields of a record.
+func fieldNames(rec any) []string {
+	var names []string
+	flatten("", rec, func(key, _ string) {
+		names = append(names, key)
+	})
+	return names
+}
+
+// Query evaluates a JSONPath expression against v. The supported subset is:
+//
+//	$                  the root (optional)
+//	.name, ['name']    a member of an object
+//	[2], [-1]          an element of a list, counting from the end if negative
+//	[1:3]              a slice of a list
+//	.*, [*]            all members or elements
+//	..name             name at any depth
+//	[?(@.a.b >= 3)]    elements matching a comparison (==, !=, <, <=, >, >=)
+//	[?(@.a)]           elements where a is present and not null or false
+//
+// A path of names and indices yields a single value, or null when it does
+// not match; any other path yields a list of all matches.
+func Query(v any, expr string) (any, error) {
+	steps, err := parseQuery(expr)
+	if err != nil {
+		return nil, fmt.Errorf("--query: %w", err)
+	}
+	t, err := Tree(v)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []any{t}
+	single := true
+	for _, s := range steps {
+		var next []any
+		for _, n := range nodes {
+			next = s.apply(n, next)
+		}
+		nodes = next
+		single = single && s.single
+	}
+
+	if single {
+		if len(nodes) == 0 {
+			return nil, nil
+		}
+		return nodes[0], nil
+	}
+	if nodes == nil {
+		nodes = []any{}
+	}
+	return nodes, nil
+}
+
+// queryStep is one step of a parsed query. apply appends the values it
+// selects from n to dst; single reports whether it selects at most one
+// value.
+type queryStep struct {
+	apply  func(n any, dst []any) []any
+	single bool
+}
+
+func parseQuery(expr string) ([]queryStep, error) {
+	p := &queryParser{s: strings.TrimSpace(expr)}
+	if strings.HasPrefix(p.s, "$") {
+		p.pos++
+	}
+	var steps []queryStep
+	for p.pos < len(p.s) {
+		s, err := p.step()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func (p *queryParser) step() (queryStep, error) {
+	switch {
+	case strings.HasPrefix(p.s[p.pos:], ".."):
+		p.pos += 2
+		name := p.name()
+		if name == "" {
+			return queryStep{}, p.errorf("expected a name after ..")
+		}
+		return queryStep{apply: func(n any, dst []any) []any { return descend(n, name, dst) }}, nil
+	case p.s[p.pos] == '.':
+		p.pos++
+		if p.pos < len(p.s) && p.s[p.pos] == '*' {
+			p.pos++
+			return queryStep{apply: wildcard}, nil
+		}
+		name := p.name()
+		if name == "" {
+			return queryStep{}, p.errorf("expected a name after .")
+		}
+		return memberStep(name), nil
+	case p.s[p.pos] == '[':
+		return p.bracket()
+	case p.pos == 0:
+		// A bare leading name, as in "forecast[0]".
+		if name := p.name(); name != "" {
+			return memberStep(name), nil
+		}
+	}
+	return queryStep{}, p.errorf("unexpected %q", p.s[p.pos])
+}
+
+// name reads an unquoted member name.
+func (p *queryParser) name() string {
+	start := p.pos
+	for p.pos < len(p.s) && isNameByte(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *queryParser) bracket() (queryStep, error) {
+	end := strings.IndexByte(p.s[p.pos:], ']')
+	if end < 0 {
+		return queryStep{}, p.errorf("unclosed [")
+	}
+	inner := strings.TrimSpace(p.s[p.pos+1 : p.pos+end])
+	// Filters may contain ']' inside quoted strings; find the closing ")]".
+	if strings.HasPrefix(inner, "?") {
+		close := indexUnquoted(p.s[p.pos:], ")]")
+		if close < 0 {
+			return queryStep{}, p.errorf("unclosed filter")
+		}
+		inner = strings.TrimSpace(p.s[p.pos+1 : p.pos+close+1])
+		end = close + 1
+	}
+	at := p.pos
+	p.pos += end + 1
+
+	switch {
+	case inner == "*":
+		return queryStep{apply: wildcard}, nil
+	case strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, `"`):
+		name, err := unquote(inner)
+		if err != nil {
+			return queryStep{}, fmt.Errorf("at %d: %w", at, err)
+		}
+		return memberStep(name), nil
+	case strings.HasPrefix(inner, "?(") && strings.HasSuffix(inner, ")"):
+		f, err := parseFilter(strings.TrimSpace(inner[2 : len(inner)-1]))
+		if err != nil {
+			return queryStep{}, fmt.Errorf("at %d: %w", at, err)
+		}
+		return queryStep{apply: func(n any, dst []any) []any {
+			for _, e := range elements(n) {
+				if f(e) {
+					dst = append(dst, e)
+				}
+			}
+			return dst
+		}}, nil
+	case strings.Contains(inner, ":"):
+		lo, hi, _ := strings.Cut(inner, ":")
+		from, errFrom := optionalInt(lo)
+		to, errTo := optionalInt(hi)
+		if errFrom != nil || errTo != nil {
+			return queryStep{}, fmt.Errorf("at %d: invalid slice [%s]", at, inner)
+		}
+		return queryStep{apply: func(n any, dst []any) []any {
+			list, ok := n.([]any)
+			if !ok {
+				return dst
+			}
+			start, end := 0, len(list)
+			if from != nil {
+				start = clampIndex(*from, len(list))
+			}
+			if to != nil {
+				end = clampIndex(*to, len(list))
+			}
+			if start < end {
+				dst = append(dst, list[start:end]...)
+			}
+			return dst
+		}}, nil
+	}
+
+	i, err := strconv.Atoi(inner)
+	if err != nil {
+		return queryStep{}, fmt.Errorf("at %d: invalid subscript [%s]", at, inner)
+	}
+	return queryStep{single: true, apply: func(n any, dst []any) []any {
+		list, ok := n.([]any)
+		if !ok {
+			return dst
+		}
+		j := i
+		if j < 0 {
+			j += len(list)
+		}
+		if j < 0 || j >= len(list) {
+			return dst
+		}
+		return append(dst, list[j])
+	}}, nil
+}
+
+func (p *queryParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at %d: "+format, append([]any{p.pos}, args...)...)
+}
+
+func memberStep(name string) queryStep {
+	return queryStep{single: true, apply: func(n any, dst []any) []any {
+		if o, ok := n.(Object); ok {
+			if v, ok := o.Get(name); ok {
+				return append(dst, v)
+			}
+		}
+		return dst
+	}}
+}
+
+// wildcard selects all members of an object or elements of a list.
+func wildcard(n any, dst []any) []any {
+	return append(dst, elements(n)...)
+}
+
+func elements(n any) []any {
+	switch n := n.(type) {
+	case []any:
+		return n
+	case Object:
+		vals := make([]any, len(n))
+		for i, m := range n {
+			vals[i] = m.Value
+		}
+		return vals
+	}
+	return nil
+}
+
+// descend appends the values of every member called name in n, at any depth.
+func descend(n any, name string, dst []any) []any {
+	if o, ok := n.(Object); ok {
+		if v, ok := o.Get(name); ok {
+			dst = append(dst, v)
+		}
+	}
+	for _, e := range elements(n) {
+		dst = descend(e, name, dst)
+	}
+	return dst
+}
+
+func optionalInt(s string) (*int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(s)
+	return &i, err
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	return max(0, min(i, n))
+}
+
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != s[len(s)-1] || (s[0] != '\'' && s[0] != '"') {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	return s[1 : len(s)-1], nil
+}
+
+// filterOps are the comparison operators of filters, longest first so that
+// "<=" is not read as "<".
+var filterOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseFilter parses the inside of [?(...)]: "@.path op literal" or "@.path".
+func parseFilter(s string) (func(any) bool, error) {
+	if !strings.HasPrefix(s, "@") {
+		return nil, fmt.Errorf("filter %q must start with @", s)
+	}
+	path, op, lit := s[1:], "", ""
+	if i, o := findFilterOp(path); i >= 0 {
+		path, op, lit = strings.TrimSpace(path[:i]), o, strings.TrimSpace(path[i+len(o):])
+	}
+	path = strings.TrimPrefix(path, ".")
+
+	get := func(n any) (any, bool) {
+		if path == "" {
+			return n, true
+		}
+		o, ok := n.(Object)
+		if !ok {
+			return nil, false
+		}
+		return lookupPath(o, path)
+	}
+	if op == "" {
+		return func(n any) bool {
+			v, ok := get(n)
+			return ok && v != nil && v != false
+		}, nil
+	}
+
+	want, err := parseLiteral(lit)
+	if err != nil {
+		return nil, err
+	}
+	return func(n any) bool {
+		v, ok := get(n)
+		if !ok {
+			return false
+		}
+		c, ok := compare(v, want)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return c == 0
+		case "!=":
+			return c != 0
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		}
+		return c >= 0
+	}, nil
+}
+
+// findFilterOp returns the position and text of the first comparison
+// operator in s outside quoted strings, or -1 when there is none.
+func findFilterOp(s string) (int, string) {
+	return indexUnquotedAny(s, filterOps)
+}
+
+// indexUnquoted returns the index of the first sep in s outside quoted
+// strings, or -1.
+func indexUnquoted(s, sep string) int {
+	i, _ := indexUnquotedAny(s, []string{sep})
+	return i
+}
+
+// indexUnquotedAny returns the position of the first of seps in s outside
+// quoted strings, and which one it is; at the same position the earlier of
+// seps wins. It returns -1 when there is none.
+func indexUnquotedAny(s string, seps []string) (int, string) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		default:
+			for _, sep := range seps {
+				if strings.HasPrefix(s[i:], sep) {
+					return i, sep
+				}
+			}
+		}
+	}
+	return -1, ""
+}
+
+// parseLiteral parses a filter operand: a number, a quoted string, true,
+// false or null.
+func parseLiteral(s string) (any, error) {
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`) {
+		return unquote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return nil, fmt.Errorf("invalid value %q: want a number, a quoted string, true, false or null", s)
+	}
+	return json.Number(s), nil
+}
+
+// compare orders two tree values of the same kind. ok is false when they
+// cannot be compared.
+func compare(a, b any) (c int, ok bool) {
+	switch a := a.(type) {
+	case json.Number:
+		bn, isNum := b.(json.Number)
+		if !isNum {
+			return 0, false
+		}
+		x, _ := a.Float64()
+		y, _ := bn.Float64()
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		bs, isStr := b.(string)
+		if !isStr {
+			return 0, false
+		}
+		return strings.Compare(a, bs), true
+	case bool:
+		// Booleans are only equal or not.
+		if bb, isBool := b.(bool); isBool && a == bb {
+			return 0, true
+		}
+	case nil:
+		if b == nil {
+			return 0, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,153 @@
+package out
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+type day struct {
+	DayDate string  `json:"dayDate"`
+	TempMax float64 `json:"temperatureMax"`
+	Rain    float64 `json:"precipitation"`
+	Slot    *slot   `json:"slot,omitempty"`
+}
+
+var days = []day{
+	{DayDate: "2026-10-18", TempMax: 14, Rain: 0},
+	{DayDate: "2026-10-19", TempMax: 11, Rain: 4.2, Slot: &slot{Start: "12:00"}},
+	{DayDate: "2026-10-20", TempMax: 9, Rain: 12},
+}
+
+func compact(t *testing.T, v any) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := writeCompactJSON(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// --- SelectFields ---
+
+func TestSelectFields_list(t *testing.T) {
+	got, err := SelectFields(days[:2], []string{"precipitation", "dayDate", "slot.start"})
+	if err != nil {
+		t.Fatalf("SelectFields() error: %v", err)
+	}
+	want := `[{"precipitation":0,"dayDate":"2026-10-18","slot.start":null},` +
+		`{"precipitation":4.2,"dayDate":"2026-10-19","slot.start":"12:00"}]`
+	if s := compact(t, got); s != want {
+		t.Errorf("SelectFields() = %s, want %s", s, want)
+	}
+}
+
+func TestSelectFields_object(t *testing.T) {
+	got, err := SelectFields(days[0], []string{"temperatureMax"})
+	if err != nil {
+		t.Fatalf("SelectFields() error: %v", err)
+	}
+	if s := compact(t, got); s != `{"temperatureMax":14}` {
+		t.Errorf("SelectFields() = %s", s)
+	}
+}
+
+func TestSelectFields_unknownField(t *testing.T) {
+	if _, err := SelectFields(days, []string{"temperatureMux"}); err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+	if _, err := SelectFields([]day{}, []string{"anything"}); err != nil {
+		t.Errorf("empty list: unexpected error: %v", err)
+	}
+}
+
+// --- Query ---
+
+func TestQuery(t *testing.T) {
+	cases := map[string]string{
+		"$":                                 compact(t, mustTree(t, days)),
+		"$[0].dayDate":                      `"2026-10-18"`,
+		"[-1].temperatureMax":               `9`,
+		"$[1:].dayDate":                     `["2026-10-19","2026-10-20"]`,
+		"$[*].precipitation":                `[0,4.2,12]`,
+		"$..start":                          `["12:00"]`,
+		"$[?(@.precipitation > 1)].dayDate": `["2026-10-19","2026-10-20"]`,
+		"$[?(@.dayDate == '2026-10-20')].temperatureMax": `[9]`,
+		"$[?(@.slot)].dayDate":                           `["2026-10-19"]`,
+		"$[5].dayDate":                                   `null`,
+		"$[?(@.precipitation > 100)]":                    `[]`,
+		"$[0]['dayDate']":                                `"2026-10-18"`,
+	}
+	for expr, want := range cases {
+		got, err := Query(days, expr)
+		if err != nil {
+			t.Errorf("Query(%q) error: %v", expr, err)
+			continue
+		}
+		if s := compact(t, got); s != want {
+			t.Errorf("Query(%q) = %s, want %s", expr, s, want)
+		}
+	}
+}
+
+func TestQuery_filterQuotedLiterals(t *testing.T) {
+	// Operators and ")]" inside string literals are part of the literal.
+	cases := map[string]string{
+		"$[?(@.dayDate != 'x)]y')].temperatureMax":  `[14,11,9]`,
+		"$[?(@.dayDate != 'a==b')].temperatureMax":  `[14,11,9]`,
+		`$[?(@.dayDate < "2026-10-18>=x")].dayDate`: `["2026-10-18"]`,
+	}
+	for expr, want := range cases {
+		got, err := Query(days, expr)
+		if err != nil {
+			t.Errorf("Query(%q) error: %v", expr, err)
+			continue
+		}
+		if s := compact(t, got); s != want {
+			t.Errorf("Query(%q) = %s, want %s", expr, s, want)
+		}
+	}
+}
+
+func TestQuery_syntaxErrors(t *testing.T) {
+	for _, expr := range []string{"$[", "$.", "$[abc]", "$[?(@.a >> 1)]", "$[?(a == 1)]", "$ foo"} {
+		if _, err := Query(days, expr); err == nil {
+			t.Errorf("Query(%q): expected error, got nil", expr)
+		}
+	}
+}
+
+func mustTree(t *testing.T, v any) any {
+	t.Helper()
+	tree, err := Tree(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+// --- RenderPlain ---
+
+func TestRenderPlain(t *testing.T) {
+	cases := []struct {
+		v    any
+		want string
+	}{
+		{json.Number("14"), "14\n"},
+		{[]any{"a", "b"}, "a\nb\n"},
+		{day{DayDate: "2026-10-18", TempMax: 14}, "dayDate:         2026-10-18\ntemperatureMax:  14\nprecipitation:   0\n"},
+		{Object{{Key: "slot.mm", Value: json.Number("2")}}, "slot.mm:  2\n"}, // as made by SelectFields
+		{days[:2], "dayDate     temperatureMax  precipitation  slot.start  slot.mm\n" +
+			"2026-10-18  14              0\n" +
+			"2026-10-19  11              4.2            12:00       0\n"},
+	}
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		if err := RenderPlain(&buf, tc.v); err != nil {
+			t.Fatalf("RenderPlain() error: %v", err)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("RenderPlain(%v) =\n%q\nwant\n%q", tc.v, buf.String(), tc.want)
+		}
+	}
+}
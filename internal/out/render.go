@@ -0,0 +1,302 @@
+package out
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Renderer writes a command result to w in one output format.
+type Renderer interface {
+	Render(w io.Writer, v any) error
+}
+
+// RenderFunc adapts a function to the Renderer interface.
+type RenderFunc func(w io.Writer, v any) error
+
+// Render calls f(w, v).
+func (f RenderFunc) Render(w io.Writer, v any) error {
+	return f(w, v)
+}
+
+// Output formats accepted by NewRenderer.
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatNDJSON   = "ndjson"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the output formats in the order they are documented.
+var Formats = []string{
+	FormatText, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatNDJSON, FormatMarkdown,
+	FormatWaybar, FormatI3blocks, FormatPolybar, FormatTmux,
+}
+
+// NewRenderer returns the renderer for format. Human-readable output is
+// specific to each command, so the text renderer is passed in.
+func NewRenderer(format string, text Renderer) (Renderer, error) {
+	switch format {
+	case FormatText, "":
+		return text, nil
+	case FormatJSON:
+		return RenderFunc(PrintJSON), nil
+	case FormatYAML:
+		return RenderFunc(renderYAML), nil
+	case FormatCSV:
+		return RenderFunc(renderCSV), nil
+	case FormatTSV:
+		return RenderFunc(renderTSV), nil
+	case FormatNDJSON:
+		return RenderFunc(renderNDJSON), nil
+	case FormatMarkdown:
+		return RenderFunc(renderMarkdown), nil
+	case FormatWaybar:
+		return statusRenderer(format, writeWaybar), nil
+	case FormatI3blocks:
+		return statusRenderer(format, writeI3blocks), nil
+	case FormatPolybar:
+		return statusRenderer(format, writePolybar), nil
+	case FormatTmux:
+		return statusRenderer(format, writeTmux), nil
+	}
+	return nil, fmt.Errorf("unknown format %q: want one of %s", format, strings.Join(Formats, ", "))
+}
+
+// RenderPlain writes v as plain text without a command's own layout, for
+// output narrowed with SelectFields or Query: a scalar as is, a list of
+// scalars one per line, an object as "key: value" lines and a list of
+// objects as a table with aligned columns.
+func RenderPlain(w io.Writer, v any) error {
+	t, err := Tree(v)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	switch t := t.(type) {
+	case Object:
+		flatten("", t, func(key, val string) {
+			fmt.Fprintf(tw, "%s:\t%s\n", key, val)
+		})
+	case []any:
+		scalars := true
+		for _, item := range t {
+			if _, ok := item.(Object); ok {
+				scalars = false
+			}
+		}
+		if scalars {
+			for _, item := range t {
+				fmt.Fprintln(tw, scalarString(item))
+			}
+			break
+		}
+		tbl, err := Tabulate(t)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(tw, strings.Join(tbl.Columns, "\t"))
+		for _, row := range tbl.Rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	default:
+		fmt.Fprintln(tw, scalarString(t))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	// Empty trailing cells leave padding behind.
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderNDJSON writes one compact JSON document per line: one per element
+// for a list, a single line otherwise.
+func renderNDJSON(w io.Writer, v any) error {
+	t, err := Tree(v)
+	if err != nil {
+		return err
+	}
+	items, ok := t.([]any)
+	if !ok {
+		items = []any{t}
+	}
+	for _, item := range items {
+		if err := writeCompactJSON(w, item); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Table is a tabular view of a value: a header and one row per record.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Tabler is implemented by results with a fixed tabular form. csv, tsv and
+// markdown use it instead of flattening the JSON output, so that the columns
+// and their order do not depend on which fields a result happens to have.
+type Tabler interface {
+	Table() Table
+}
+
+// tableOf returns the table of v: its own if it is a Tabler, otherwise the
+// flattened JSON output.
+func tableOf(v any) (Table, error) {
+	if t, ok := v.(Tabler); ok {
+		return t.Table(), nil
+	}
+	return Tabulate(v)
+}
+
+// Tabulate flattens v into a table. A list becomes one row per element, any
+// other value a single row. Nested objects are flattened into dotted column
+// names (e.g. "slot.start"); nested lists are kept as compact JSON.
+func Tabulate(v any) (Table, error) {
+	t, err := Tree(v)
+	if err != nil {
+		return Table{}, err
+	}
+	items, ok := t.([]any)
+	if !ok {
+		items = []any{t}
+	}
+
+	var tbl Table
+	index := map[string]int{}
+	records := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		rec := map[string]string{}
+		flatten("", item, func(key, val string) {
+			if _, ok := index[key]; !ok {
+				index[key] = len(tbl.Columns)
+				tbl.Columns = append(tbl.Columns, key)
+			}
+			rec[key] = val
+		})
+		records = append(records, rec)
+	}
+	for _, rec := range records {
+		row := make([]string, len(tbl.Columns))
+		for i, col := range tbl.Columns {
+			row[i] = rec[col]
+		}
+		tbl.Rows = append(tbl.Rows, row)
+	}
+	return tbl, nil
+}
+
+func flatten(prefix string, v any, emit func(key, val string)) {
+	obj, ok := v.(Object)
+	if !ok {
+		key := prefix
+		if key == "" {
+			key = "value"
+		}
+		emit(key, scalarString(v))
+		return
+	}
+	for _, m := range obj {
+		key := m.Key
+		if prefix != "" {
+			key = prefix + "." + m.Key
+		}
+		flatten(key, m.Value, emit)
+	}
+}
+
+// renderCSV writes a table as RFC 4180 CSV.
+func renderCSV(w io.Writer, v any) error {
+	tbl, err := tableOf(v)
+	if err != nil {
+		return err
+	}
+	if len(tbl.Columns) == 0 {
+		return nil
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(tbl.Columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(tbl.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// renderTSV writes a table as tab-separated values. TSV has no quoting, so
+// tabs and line breaks inside a cell are replaced by spaces.
+func renderTSV(w io.Writer, v any) error {
+	tbl, err := tableOf(v)
+	if err != nil {
+		return err
+	}
+	if len(tbl.Columns) == 0 {
+		return nil
+	}
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	line := func(cells []string) error {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = clean.Replace(c)
+		}
+		_, err := fmt.Fprintln(w, strings.Join(escaped, "\t"))
+		return err
+	}
+	if err := line(tbl.Columns); err != nil {
+		return err
+	}
+	for _, row := range tbl.Rows {
+		if err := line(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderMarkdown(w io.Writer, v any) error {
+	tbl, err := tableOf(v)
+	if err != nil {
+		return err
+	}
+	if len(tbl.Columns) == 0 {
+		return nil
+	}
+	cell := strings.NewReplacer("|", `\|`, "\n", "<br>", "\r", "")
+	line := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = cell.Replace(c)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+	}
+	line(tbl.Columns)
+	rule := make([]string, len(tbl.Columns))
+	for i := range rule {
+		rule[i] = "---"
+	}
+	line(rule)
+	for _, row := range tbl.Rows {
+		line(row)
+	}
+	return nil
+}
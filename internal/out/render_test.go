@@ -0,0 +1,212 @@
+package out
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+type slot struct {
+	Start string  `json:"start"`
+	MM    float64 `json:"mm"`
+}
+
+type sample struct {
+	Name    string   `json:"name"`
+	Temp    float64  `json:"temp"`
+	Wet     bool     `json:"wet"`
+	Regions []string `json:"regions,omitempty"`
+	Slot    *slot    `json:"slot,omitempty"`
+}
+
+func render(t *testing.T, format string, v any) string {
+	t.Helper()
+	r, err := NewRenderer(format, nil)
+	if err != nil {
+		t.Fatalf("NewRenderer(%q) error: %v", format, err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, v); err != nil {
+		t.Fatalf("Render(%q) error: %v", format, err)
+	}
+	return buf.String()
+}
+
+// --- NewRenderer ---
+
+func TestNewRenderer_unknownFormat(t *testing.T) {
+	if _, err := NewRenderer("xml", nil); err == nil {
+		t.Error("expected error for unknown format, got nil")
+	}
+}
+
+func TestNewRenderer_textUsesCommandRenderer(t *testing.T) {
+	text := RenderFunc(func(w io.Writer, v any) error {
+		_, err := io.WriteString(w, "hello\n")
+		return err
+	})
+	r, err := NewRenderer(FormatText, text)
+	if err != nil {
+		t.Fatalf("NewRenderer() error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, nil); err != nil || buf.String() != "hello\n" {
+		t.Errorf("text Render() = %q, %v; want %q", buf.String(), err, "hello\n")
+	}
+}
+
+// --- Tree ---
+
+func TestTree_keepsFieldOrder(t *testing.T) {
+	tree, err := Tree(sample{Name: "Zurich", Temp: 12.5})
+	if err != nil {
+		t.Fatalf("Tree() error: %v", err)
+	}
+	obj, ok := tree.(Object)
+	if !ok {
+		t.Fatalf("Tree() = %T, want Object", tree)
+	}
+	var keys []string
+	for _, m := range obj {
+		keys = append(keys, m.Key)
+	}
+	if got := strings.Join(keys, ","); got != "name,temp,wet" {
+		t.Errorf("keys = %s, want name,temp,wet", got)
+	}
+	if v, _ := obj.Get("temp"); scalarString(v) != "12.5" {
+		t.Errorf("temp = %v, want 12.5", v)
+	}
+}
+
+// --- YAML ---
+
+func TestRenderYAML(t *testing.T) {
+	got := render(t, FormatYAML, []sample{
+		{Name: "Zurich", Temp: 12.5, Regions: []string{"ZH", "true"}, Slot: &slot{Start: "2026-10-18T12:00:00Z", MM: 0.4}},
+		{Name: "", Temp: -1},
+	})
+	want := `- name: Zurich
+  temp: 12.5
+  wet: false
+  regions:
+    - ZH
+    - "true"
+  slot:
+    start: "2026-10-18T12:00:00Z"
+    mm: 0.4
+- name: ""
+  temp: -1
+  wet: false
+`
+	if got != want {
+		t.Errorf("YAML =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderYAML_emptyList(t *testing.T) {
+	if got := render(t, FormatYAML, []string{}); got != "[]\n" {
+		t.Errorf("YAML(empty list) = %q, want %q", got, "[]\n")
+	}
+}
+
+func TestNeedsQuotes(t *testing.T) {
+	cases := map[string]bool{
+		"Zurich":       false,
+		"light rain":   false,
+		"":             true,
+		"no":           true,
+		"8000":         true,
+		"- item":       true,
+		"key: value":   true,
+		"trailing ":    true,
+		"line\nbreak":  true,
+		"Sonne & Wind": false,
+	}
+	for s, want := range cases {
+		if got := needsQuotes(s); got != want {
+			t.Errorf("needsQuotes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+// --- CSV / TSV / Markdown ---
+
+func TestRenderCSV_flattensNestedObjects(t *testing.T) {
+	got := render(t, FormatCSV, []sample{
+		{Name: "Zurich", Temp: 12.5},
+		{Name: "Bern, BE", Temp: 11, Slot: &slot{Start: "12:00", MM: 0.2}},
+	})
+	want := "name,temp,wet,slot.start,slot.mm\n" +
+		"Zurich,12.5,false,,\n" +
+		"\"Bern, BE\",11,false,12:00,0.2\n"
+	if got != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderTSV_singleObject(t *testing.T) {
+	got := render(t, FormatTSV, sample{Name: "Zurich", Regions: []string{"ZH"}})
+	want := "name\ttemp\twet\tregions\nZurich\t0\tfalse\t[\"ZH\"]\n"
+	if got != want {
+		t.Errorf("TSV = %q, want %q", got, want)
+	}
+}
+
+func TestRenderCSV_emptyList(t *testing.T) {
+	if got := render(t, FormatCSV, []sample{}); got != "" {
+		t.Errorf("CSV(empty list) = %q, want empty", got)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	got := render(t, FormatMarkdown, []sample{{Name: "a|b", Temp: 1}})
+	want := "| name | temp | wet |\n| --- | --- | --- |\n| a\\|b | 1 | false |\n"
+	if got != want {
+		t.Errorf("Markdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// --- NDJSON ---
+
+func TestRenderNDJSON(t *testing.T) {
+	got := render(t, FormatNDJSON, []sample{{Name: "Zurich"}, {Name: "Bern"}})
+	want := `{"name":"Zurich","temp":0,"wet":false}` + "\n" + `{"name":"Bern","temp":0,"wet":false}` + "\n"
+	if got != want {
+		t.Errorf("NDJSON =\n%s\nwant\n%s", got, want)
+	}
+	if got := render(t, FormatNDJSON, sample{Name: "Sion"}); strings.Count(got, "\n") != 1 {
+		t.Errorf("NDJSON(object) = %q, want a single line", got)
+	}
+}
+
+// --- Sep ---
+
+func TestSep(t *testing.T) {
+	var buf bytes.Buffer
+	Sep(&buf, 3)
+	if got := buf.String(); got != "───\n" {
+		t.Errorf("Sep(3) = %q, want %q", got, "───\n")
+	}
+}
+
+// --- Tabler ---
+
+type fixedTable []string
+
+func (f fixedTable) Table() Table {
+	tbl := Table{Columns: []string{"name", "extra"}}
+	for _, name := range f {
+		tbl.Rows = append(tbl.Rows, []string{name, ""})
+	}
+	return tbl
+}
+
+func TestRenderCSV_usesTabler(t *testing.T) {
+	if got := render(t, FormatCSV, fixedTable{"a"}); got != "name,extra\na,\n" {
+		t.Errorf("CSV = %q", got)
+	}
+	if got := render(t, FormatTSV, fixedTable{}); got != "name\textra\n" {
+		t.Errorf("TSV(empty) = %q, want the header only", got)
+	}
+}
@@ -0,0 +1,136 @@
+package out
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Status-bar formats accepted by NewRenderer.
+const (
+	FormatWaybar   = "waybar"
+	FormatI3blocks = "i3blocks"
+	FormatPolybar  = "polybar"
+	FormatTmux     = "tmux"
+)
+
+// IsStatusFormat reports whether format is one of the status-bar formats.
+func IsStatusFormat(format string) bool {
+	switch format {
+	case FormatWaybar, FormatI3blocks, FormatPolybar, FormatTmux:
+		return true
+	}
+	return false
+}
+
+// Status classes, from least to most severe.
+const (
+	StatusNormal   = "normal"
+	StatusRain     = "rain"     // rain is imminent
+	StatusNotice   = "notice"   // warning level 2 is active
+	StatusWarning  = "warning"  // warning level 3 is active
+	StatusCritical = "critical" // warning level 4 or 5 is active
+)
+
+// statusColors are the colours used by the formats that embed them directly;
+// waybar leaves styling to CSS through the class.
+var statusColors = map[string]string{
+	StatusRain:     "#61afef",
+	StatusNotice:   "#e5c07b",
+	StatusWarning:  "#d19a66",
+	StatusCritical: "#e06c75",
+}
+
+// Status is a one-line summary of a result for status bars.
+type Status struct {
+	Text       string // full text, e.g. "☀️ 14°"
+	Short      string // abbreviated text for narrow bars; defaults to Text
+	Tooltip    string // multi-line details
+	Class      string // one of the Status* classes
+	Percentage int    // 0–100, for waybar format-icons
+}
+
+// StatusReporter is implemented by results that can be shown in a status
+// bar.
+type StatusReporter interface {
+	Status() Status
+}
+
+// StatusClass picks the class for the highest active warning level and
+// whether rain is imminent. Warnings of level 2 and above take precedence
+// over rain.
+func StatusClass(warnLevel int, rainSoon bool) string {
+	switch {
+	case warnLevel >= 4:
+		return StatusCritical
+	case warnLevel == 3:
+		return StatusWarning
+	case warnLevel == 2:
+		return StatusNotice
+	case rainSoon:
+		return StatusRain
+	}
+	return StatusNormal
+}
+
+// statusRenderer wraps a status-bar writer so that it rejects results that
+// have no status form.
+func statusRenderer(format string, write func(w io.Writer, s Status) error) Renderer {
+	return RenderFunc(func(w io.Writer, v any) error {
+		r, ok := v.(StatusReporter)
+		if !ok {
+			return fmt.Errorf("--format %s is not supported by this command", format)
+		}
+		s := r.Status()
+		if s.Class == "" {
+			s.Class = StatusNormal
+		}
+		if s.Short == "" {
+			s.Short = s.Text
+		}
+		return write(w, s)
+	})
+}
+
+// writeWaybar writes the JSON object read by waybar's custom modules with
+// "return-type": "json".
+func writeWaybar(w io.Writer, s Status) error {
+	return writeCompactJSON(w, Object{
+		{Key: "text", Value: s.Text},
+		{Key: "alt", Value: s.Class},
+		{Key: "tooltip", Value: s.Tooltip},
+		{Key: "class", Value: s.Class},
+		{Key: "percentage", Value: s.Percentage},
+	})
+}
+
+// writeI3blocks writes the full text, short text and colour lines of the
+// i3blocks protocol.
+func writeI3blocks(w io.Writer, s Status) error {
+	_, err := fmt.Fprintf(w, "%s\n%s\n%s\n", oneLine(s.Text), oneLine(s.Short), statusColors[s.Class])
+	return err
+}
+
+// writePolybar writes the text with a %{F} foreground colour tag.
+func writePolybar(w io.Writer, s Status) error {
+	text := strings.ReplaceAll(oneLine(s.Text), "%", "%%")
+	if c := statusColors[s.Class]; c != "" {
+		text = "%{F" + c + "}" + text + "%{F-}"
+	}
+	_, err := fmt.Fprintln(w, text)
+	return err
+}
+
+// writeTmux writes the text with a #[fg=...] style for status-left/right.
+func writeTmux(w io.Writer, s Status) error {
+	text := strings.ReplaceAll(oneLine(s.Text), "#", "##")
+	if c := statusColors[s.Class]; c != "" {
+		text = "#[fg=" + c + "]" + text + "#[default]"
+	}
+	_, err := fmt.Fprintln(w, text)
+	return err
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,68 @@
+package out
+
+import (
+	"bytes"
+	"testing"
+)
+
+type bar struct{ s Status }
+
+func (b bar) Status() Status { return b.s }
+
+// --- StatusClass ---
+
+func TestStatusClass(t *testing.T) {
+	cases := []struct {
+		level int
+		rain  bool
+		want  string
+	}{
+		{0, false, StatusNormal},
+		{1, false, StatusNormal},
+		{0, true, StatusRain},
+		{1, true, StatusRain},
+		{2, true, StatusNotice},
+		{3, false, StatusWarning},
+		{4, false, StatusCritical},
+		{5, true, StatusCritical},
+	}
+	for _, tc := range cases {
+		if got := StatusClass(tc.level, tc.rain); got != tc.want {
+			t.Errorf("StatusClass(%d, %v) = %q, want %q", tc.level, tc.rain, got, tc.want)
+		}
+	}
+}
+
+// --- status-bar formats ---
+
+func TestStatusFormats(t *testing.T) {
+	v := bar{Status{Text: "🌧️ 14:20 #1 50%", Tooltip: "Rain from ~14:20\nlight", Class: StatusRain, Percentage: 50}}
+	cases := map[string]string{
+		FormatWaybar:   `{"text":"🌧️ 14:20 #1 50%","alt":"rain","tooltip":"Rain from ~14:20\nlight","class":"rain","percentage":50}`,
+		FormatI3blocks: "🌧️ 14:20 #1 50%\n🌧️ 14:20 #1 50%\n#61afef\n",
+		FormatPolybar:  "%{F#61afef}🌧️ 14:20 #1 50%%%{F-}\n",
+		FormatTmux:     "#[fg=#61afef]🌧️ 14:20 ##1 50%#[default]\n",
+	}
+	for format, want := range cases {
+		if got := render(t, format, v); got != want {
+			t.Errorf("--format %s = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestStatusFormats_normalHasNoColour(t *testing.T) {
+	v := bar{Status{Text: "☀️ 14°"}}
+	if got := render(t, FormatTmux, v); got != "☀️ 14°\n" {
+		t.Errorf("tmux = %q, want plain text", got)
+	}
+	if got := render(t, FormatI3blocks, v); got != "☀️ 14°\n☀️ 14°\n\n" {
+		t.Errorf("i3blocks = %q, want an empty colour line", got)
+	}
+}
+
+func TestStatusFormats_unsupportedValue(t *testing.T) {
+	r, _ := NewRenderer(FormatWaybar, nil)
+	if err := r.Render(&bytes.Buffer{}, []string{"x"}); err == nil {
+		t.Error("expected error for a value without Status, got nil")
+	}
+}
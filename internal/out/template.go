@@ -0,0 +1,29 @@
+package out
+
+import (
+	"bytes"
+	"io"
+	"text/template"
+)
+
+// NewTemplateRenderer returns a renderer that executes the Go template text
+// against the value being rendered, i.e. the same struct the JSON output is
+// built from. funcs are made available to the template. A trailing newline
+// is added when the template output lacks one.
+func NewTemplateRenderer(text string, funcs template.FuncMap) (Renderer, error) {
+	tmpl, err := template.New("output").Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return RenderFunc(func(w io.Writer, v any) error {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, v); err != nil {
+			return err
+		}
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		_, err := w.Write(buf.Bytes())
+		return err
+	}), nil
+}
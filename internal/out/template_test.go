@@ -0,0 +1,47 @@
+package out
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// --- NewTemplateRenderer ---
+
+func TestNewTemplateRenderer(t *testing.T) {
+	funcs := template.FuncMap{"upper": strings.ToUpper}
+	r, err := NewTemplateRenderer(`{{.Name | upper}} {{.Temp | printf "%.0f"}}°`, funcs)
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, sample{Name: "Zurich", Temp: 12.6}); err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if got := buf.String(); got != "ZURICH 13°\n" {
+		t.Errorf("Render() = %q, want %q", got, "ZURICH 13°\n")
+	}
+}
+
+func TestNewTemplateRenderer_keepsTrailingNewline(t *testing.T) {
+	r, _ := NewTemplateRenderer("{{.Name}}\n", nil)
+	var buf bytes.Buffer
+	_ = r.Render(&buf, sample{Name: "Bern"})
+	if got := buf.String(); got != "Bern\n" {
+		t.Errorf("Render() = %q, want %q", got, "Bern\n")
+	}
+}
+
+func TestNewTemplateRenderer_errors(t *testing.T) {
+	if _, err := NewTemplateRenderer("{{.Name", nil); err == nil {
+		t.Error("expected parse error, got nil")
+	}
+	r, err := NewTemplateRenderer("{{.Missing}}", nil)
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error: %v", err)
+	}
+	if err := r.Render(&bytes.Buffer{}, sample{}); err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+}
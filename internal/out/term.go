@@ -0,0 +1,47 @@
+package out
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultWidth is the line width assumed when the output is not a terminal
+// and $COLUMNS is not set.
+const DefaultWidth = 80
+
+// Width returns the number of columns available on w: the terminal width
+// when w is a terminal, otherwise $COLUMNS, otherwise DefaultWidth. Screen
+// reader output has no width limit.
+func Width(w io.Writer) int {
+	if tw, ok := w.(*TextWriter); ok {
+		if tw.mode == TextScreenReader {
+			return screenReaderWidth
+		}
+		w = tw.w
+	}
+	if f, ok := w.(*os.File); ok {
+		if n, ok := terminalWidth(f); ok && n > 0 {
+			return n
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return DefaultWidth
+}
+
+// UnicodeLocale reports whether the locale announces UTF-8, following the
+// usual precedence of $LC_ALL, $LC_CTYPE and $LANG. Without any locale
+// UTF-8 is assumed, as on most current systems; LANG=C or a Latin-1 locale
+// rules it out.
+func UnicodeLocale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return true
+}
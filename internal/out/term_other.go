@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly || windows)
+
+package out
+
+import "os"
+
+// terminalWidth is not implemented on this platform; Width falls back to
+// $COLUMNS.
+func terminalWidth(f *os.File) (int, bool) {
+	return 0, false
+}
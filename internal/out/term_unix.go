@@ -0,0 +1,22 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package out
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminalWidth asks the terminal behind f for its size with TIOCGWINSZ. ok
+// is false when f is not a terminal.
+func terminalWidth(f *os.File) (int, bool) {
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0, false
+	}
+	return int(ws.Col), true
+}
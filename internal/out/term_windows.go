@@ -0,0 +1,27 @@
+//go:build windows
+
+package out
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var procGetConsoleScreenBufferInfo = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleScreenBufferInfo")
+
+// terminalWidth asks the console behind f for the width of its window. ok is
+// false when f is not a console.
+func terminalWidth(f *os.File) (int, bool) {
+	var info struct {
+		Size, CursorPosition     struct{ X, Y int16 }
+		Attributes               uint16
+		Left, Top, Right, Bottom int16
+		MaximumWindowSize        struct{ X, Y int16 }
+	}
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0, false
+	}
+	return int(info.Right-info.Left) + 1, true
+}
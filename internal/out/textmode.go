@@ -0,0 +1,219 @@
+package out
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextMode selects how human-readable output is drawn.
+type TextMode int
+
+const (
+	// TextRich uses Unicode symbols, emoji and box-drawing separators.
+	TextRich TextMode = iota
+	// TextASCII limits output to printable ASCII: emoji become text labels
+	// and symbols such as "─", "→" and "°" ASCII equivalents.
+	TextASCII
+	// TextScreenReader is TextASCII written as complete sentences: tables
+	// are read row by row, and separators, alignment and line wrapping are
+	// left out.
+	TextScreenReader
+)
+
+// screenReaderWidth is the width reported for screen-reader output, wide
+// enough that nothing is wrapped or shortened.
+const screenReaderWidth = 1 << 15
+
+// TextWriter writes human-readable output in a TextMode. It works line by
+// line, so Flush must be called once the output is complete.
+type TextWriter struct {
+	w    io.Writer
+	mode TextMode
+	buf  []byte
+	err  error
+}
+
+// NewTextWriter returns a TextWriter writing to w in mode.
+func NewTextWriter(w io.Writer, mode TextMode) *TextWriter {
+	return &TextWriter{w: w, mode: mode}
+}
+
+// ModeOf returns the TextMode of w: the mode of a TextWriter, otherwise
+// TextRich.
+func ModeOf(w io.Writer) TextMode {
+	if tw, ok := w.(*TextWriter); ok {
+		return tw.mode
+	}
+	return TextRich
+}
+
+// Write buffers p and writes the complete lines in it.
+func (tw *TextWriter) Write(p []byte) (int, error) {
+	if tw.err != nil {
+		return 0, tw.err
+	}
+	tw.buf = append(tw.buf, p...)
+	for {
+		i := bytes.IndexByte(tw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		tw.writeLine(string(tw.buf[:i]), true)
+		tw.buf = tw.buf[i+1:]
+	}
+	return len(p), tw.err
+}
+
+// Flush writes the last line if it was not terminated by a newline.
+func (tw *TextWriter) Flush() error {
+	if len(tw.buf) > 0 {
+		tw.writeLine(string(tw.buf), false)
+		tw.buf = nil
+	}
+	return tw.err
+}
+
+func (tw *TextWriter) writeLine(line string, newline bool) {
+	if tw.err != nil {
+		return
+	}
+	switch tw.mode {
+	case TextASCII:
+		line = ASCII(line)
+	case TextScreenReader:
+		if line = sentence(ASCII(line)); line == "" {
+			return
+		}
+	}
+	if newline {
+		line += "\n"
+	}
+	_, tw.err = io.WriteString(tw.w, line)
+}
+
+// sentence turns a line of text output into a sentence: spaces used for
+// alignment are collapsed and the line ends with a full stop. Blank lines
+// and separator lines yield "".
+func sentence(line string) string {
+	line = strings.Join(strings.Fields(line), " ")
+	if strings.Trim(line, "-=") == "" {
+		return ""
+	}
+	line = strings.ReplaceAll(line, " : ", ": ")
+	switch line[len(line)-1] {
+	case '.', '!', '?', ':':
+		return line
+	}
+	return line + "."
+}
+
+// Transliterate returns s as it will appear on w, so that text can be
+// measured before it is written.
+func Transliterate(w io.Writer, s string) string {
+	if ModeOf(w) == TextRich {
+		return s
+	}
+	return ASCII(s)
+}
+
+// asciiSymbols replaces symbols with ASCII equivalents.
+var asciiSymbols = strings.NewReplacer(
+	"─", "-", "—", "-", "–", "-", "→", "->", "←", "<-", "…", "...",
+	"≥", ">=", "≤", "<=", "·", "-", "×", "x", "•", "*",
+	"“", `"`, "”", `"`, "„", `"`, "«", `"`, "»", `"`, "‘", "'", "’", "'",
+	"\u00a0", " ",
+	"ä", "ae", "ö", "oe", "ü", "ue", "Ä", "Ae", "Ö", "Oe", "Ü", "Ue",
+	"à", "a", "â", "a", "á", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "í", "i", "ì", "i", "ô", "o", "ó", "o", "ò", "o",
+	"û", "u", "ù", "u", "ú", "u", "ÿ", "y", "ñ", "n", "ß", "ss", "œ", "oe", "æ", "ae",
+	"À", "A", "Â", "A", "Ç", "C", "É", "E", "È", "E", "Ê", "E", "Î", "I", "Ô", "O", "Û", "U",
+)
+
+// emojiLabels names the emoji used in text output, by their first code
+// point.
+var emojiLabels = map[rune]string{
+	'☀': "sun", '🌤': "sun", '⛅': "cloud", '🌥': "cloud", '☁': "cloud",
+	'🌦': "showers", '🌧': "rain", '☔': "rain", '🌨': "snow", '❄': "snow",
+	'⛈': "thunderstorm", '🌫': "fog", '🌙': "moon", '⚠': "warning",
+}
+
+// ASCII transliterates s to printable ASCII. Emoji become their label in
+// brackets, as in "[rain]", except that an emoji in parentheses after a
+// description, as in "Sunny (☀️)", is left out. "°C" and "°F" become "C"
+// and "F", a bare degree sign becomes "deg", and characters without an
+// equivalent become "?". ANSI escape sequences are
+// kept.
+func ASCII(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	cs := clusters(asciiSymbols.Replace(s))
+	var b strings.Builder
+	var last byte
+	write := func(s string) {
+		b.WriteString(s)
+		last = s[len(s)-1]
+	}
+	for i := 0; i < len(cs); i++ {
+		c := cs[i]
+		r := firstRune(c)
+		switch {
+		case escapeLen(c) > 0:
+			b.WriteString(c)
+		case r < utf8.RuneSelf:
+			// Combining marks on ASCII letters are dropped.
+			write(string(r))
+		case r == '°':
+			// "12 °C", "Min°F" and "12°" become "12 C", "Min F" and
+			// "12 deg".
+			if last != 0 && last != ' ' {
+				write(" ")
+			}
+			if i+1 < len(cs) && (cs[i+1] == "C" || cs[i+1] == "F") {
+				write(cs[i+1])
+				i++
+			} else {
+				write("deg")
+			}
+		case isEmoji(c):
+			if last == '(' && strings.HasSuffix(b.String(), " (") && i+1 < len(cs) && cs[i+1] == ")" {
+				str := b.String()
+				b.Reset()
+				b.WriteString(str[:len(str)-2])
+				last = 0
+				if str = b.String(); str != "" {
+					last = str[len(str)-1]
+				}
+				i++
+				continue
+			}
+			label := emojiLabels[r]
+			if label == "" {
+				label = "?"
+			}
+			write("[" + label + "]")
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		default:
+			write("?")
+		}
+	}
+	return b.String()
+}
+
+// isEmoji reports whether the cluster c is drawn as an emoji.
+func isEmoji(c string) bool {
+	r := firstRune(c)
+	return emojiLabels[r] != "" || r >= 0x1f000 || strings.ContainsRune(c, emojiPresentation)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package out
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// --- ASCII ---
+
+func TestASCII(t *testing.T) {
+	cases := map[string]string{
+		"plain text":                "plain text",
+		"Sunny (☀️)":                "Sunny",
+		"☀️ 14°":                    "[sun] 14 deg",
+		"🌧️ 9° ☔":                   "[rain] 9 deg [rain]",
+		"Min°C":                     "Min C",
+		"12.5 °C":                   "12.5 C",
+		"54.5 °F":                   "54.5 F",
+		"Min°F":                     "Min F",
+		"68°/54°":                   "68 deg/54 deg",
+		"Wind — Considerable":       "Wind - Considerable",
+		"14:00 → 16:00":             "14:00 -> 16:00",
+		"──────":                    "------",
+		"Zürich, Genève, Neuchâtel": "Zuerich, Geneve, Neuchatel",
+		"≥0.10 mm…":                 ">=0.10 mm...",
+		"\x1b[1;31m☔\x1b[0m":        "\x1b[1;31m[rain]\x1b[0m",
+		"東京":                        "??",
+		"e\u0301":                   "e",
+		"🦄":                         "[?]",
+	}
+	for in, want := range cases {
+		if got := ASCII(in); got != want {
+			t.Errorf("ASCII(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// --- TextWriter ---
+
+func TestTextWriter_rich(t *testing.T) {
+	var buf bytes.Buffer
+	tw := NewTextWriter(&buf, TextRich)
+	fmt.Fprint(tw, "☀️ 14°\nno newline")
+	if err := tw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "☀️ 14°\nno newline" {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestTextWriter_screenReader(t *testing.T) {
+	var buf bytes.Buffer
+	tw := NewTextWriter(&buf, TextScreenReader)
+	Sep(tw, 20)
+	fmt.Fprintln(tw, "  Weather for PLZ 8000")
+	Sep(tw, 20)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "  Temperature : 12.5 °C")
+	fmt.Fprint(tw, "  Is it going to rain?")
+	_ = tw.Flush()
+	want := "Weather for PLZ 8000.\nTemperature: 12.5 C.\nIs it going to rain?"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if Width(tw) <= 1000 {
+		t.Errorf("Width(screen reader) = %d, want no limit", Width(tw))
+	}
+}
+
+// --- TextTable.Layout ---
+
+func TestTextTable_layoutModes(t *testing.T) {
+	tbl := TextTable{
+		Columns: []Column{{Header: "Date"}, {Header: "Conditions", Min: 8}, {Header: "Max°C", Right: true}},
+		Rows: [][]string{
+			{"2026-02-20", "Light snow showers (🌨️)", "-1.0"},
+			{"2026-02-21", "", "4.5"},
+		},
+	}
+	t.Setenv("COLUMNS", "31")
+
+	header, rows, _ := tbl.Layout(NewTextWriter(&bytes.Buffer{}, TextASCII))
+	if header != "Date        Conditions    Max C" {
+		t.Errorf("ASCII header = %q", header)
+	}
+	if rows[0] != "2026-02-20  Light sno...   -1.0" {
+		t.Errorf("ASCII row = %q", rows[0])
+	}
+
+	header, rows, _ = tbl.Layout(NewTextWriter(&bytes.Buffer{}, TextScreenReader))
+	if header != "" {
+		t.Errorf("screen reader header = %q, want none", header)
+	}
+	if rows[0] != "Date: 2026-02-20; Conditions: Light snow showers; Max C: -1.0" ||
+		rows[1] != "Date: 2026-02-21; Max C: 4.5" {
+		t.Errorf("screen reader rows = %q", rows)
+	}
+}
@@ -0,0 +1,155 @@
+package out
+
+import (
+	"io"
+	"strings"
+)
+
+// Column describes a column of a TextTable.
+type Column struct {
+	Header string
+	// Right aligns the cells to the right, as for numbers.
+	Right bool
+	// Min is the width the column may be shrunk to on a narrow terminal, its
+	// cells being truncated with "…". Zero means it keeps its full width.
+	Min int
+	// Drop marks a column that may be left out on a narrow terminal when
+	// shrinking is not enough. Columns with a higher Drop go first.
+	Drop int
+}
+
+// TextTable lays out human-readable tables. Cells are measured by display
+// width, so emoji and wide characters keep the columns aligned, and the
+// table is fitted to the terminal by shrinking and then dropping columns.
+type TextTable struct {
+	Columns []Column
+	Rows    [][]string
+	// Indent is the number of spaces before the first column.
+	Indent int
+}
+
+// Layout lays out the table for w: fitted to its width, in ASCII for ASCII
+// output, and for screen readers as one sentence per row naming each cell
+// by its header, with an empty header line.
+func (t TextTable) Layout(w io.Writer) (header string, rows []string, used int) {
+	mode := ModeOf(w)
+	if mode == TextRich {
+		return t.Lines(Width(w))
+	}
+	t.Columns = append([]Column(nil), t.Columns...)
+	for i := range t.Columns {
+		t.Columns[i].Header = ASCII(t.Columns[i].Header)
+	}
+	cells := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		cells[i] = make([]string, len(row))
+		for j, cell := range row {
+			cells[i][j] = ASCII(cell)
+		}
+	}
+	t.Rows = cells
+
+	if mode == TextScreenReader {
+		for _, row := range t.Rows {
+			var parts []string
+			for i, c := range t.Columns {
+				if i < len(row) && row[i] != "" {
+					parts = append(parts, c.Header+": "+row[i])
+				}
+			}
+			rows = append(rows, strings.Join(parts, "; "))
+		}
+		return "", rows, 0
+	}
+	return t.lines(Width(w), "...")
+}
+
+// Lines lays out the table in at most width cells and returns the header
+// line, one line per row and the width of the widest line.
+func (t TextTable) Lines(width int) (header string, rows []string, used int) {
+	return t.lines(width, "…")
+}
+
+func (t TextTable) lines(width int, ellipsis string) (header string, rows []string, used int) {
+	const gap = 2
+
+	widths := make([]int, len(t.Columns))
+	for i, c := range t.Columns {
+		widths[i] = StringWidth(c.Header)
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], StringWidth(cell))
+		}
+	}
+
+	keep := make([]bool, len(t.Columns))
+	for i := range keep {
+		keep[i] = true
+	}
+	total := func() int {
+		n, cols := t.Indent, 0
+		for i, w := range widths {
+			if keep[i] {
+				n += w
+				cols++
+			}
+		}
+		return n + gap*max(cols-1, 0)
+	}
+
+	for excess := total() - width; excess > 0; excess = total() - width {
+		// Shrink the column with the most room to give first.
+		best, slack := -1, 0
+		for i, c := range t.Columns {
+			if s := widths[i] - c.Min; keep[i] && c.Min > 0 && s > slack {
+				best, slack = i, s
+			}
+		}
+		if best >= 0 {
+			widths[best] -= min(excess, slack)
+			continue
+		}
+		// Then leave out the most expendable column.
+		best = -1
+		for i, c := range t.Columns {
+			if keep[i] && c.Drop > 0 && (best < 0 || c.Drop > t.Columns[best].Drop) {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+		keep[best] = false
+	}
+
+	line := func(cells []string) string {
+		parts := make([]string, 0, len(cells))
+		for i, c := range t.Columns {
+			if !keep[i] {
+				continue
+			}
+			cell := ""
+			if i < len(cells) {
+				cell = truncate(cells[i], widths[i], ellipsis)
+			}
+			if c.Right {
+				cell = PadLeft(cell, widths[i])
+			} else {
+				cell = PadRight(cell, widths[i])
+			}
+			parts = append(parts, cell)
+		}
+		return strings.TrimRight(strings.Repeat(" ", t.Indent)+strings.Join(parts, strings.Repeat(" ", gap)), " ")
+	}
+
+	headers := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		headers[i] = c.Header
+	}
+	header = line(headers)
+	for _, row := range t.Rows {
+		rows = append(rows, line(row))
+	}
+	return header, rows, total()
+}
@@ -0,0 +1,257 @@
+package out
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Display width of text in a terminal. Most characters take one cell; East
+// Asian wide characters and emoji take two, and combining marks, variation
+// selectors, joiners and colour escape sequences take none. A narrow symbol
+// followed by the emoji variation selector U+FE0F, as in "☀️", is drawn as a
+// two-cell emoji.
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	textPresentation  = '\ufe0e'
+	emojiPresentation = '\ufe0f'
+)
+
+// wideRanges are the code point ranges drawn two cells wide: the East Asian
+// Wide and Fullwidth blocks and the emoji that default to emoji
+// presentation.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec},
+	{0x23f0, 0x23f0}, {0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce},
+	{0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27b0, 0x27b0}, {0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x303e},
+	{0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xa960, 0xa97f}, {0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f}, {0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x1f004, 0x1f004},
+	{0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a}, {0x1f200, 0x1f251},
+	{0x1f300, 0x1f64f}, {0x1f680, 0x1f6ff}, {0x1f7e0, 0x1f7eb}, {0x1f900, 0x1f9ff},
+	{0x1fa70, 0x1faff}, {0x20000, 0x3fffd},
+}
+
+func isWide(r rune) bool {
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	return i < len(wideRanges) && wideRanges[i][0] <= r
+}
+
+// isExtending reports whether r extends the preceding character rather than
+// starting a new one.
+func isExtending(r rune) bool {
+	switch {
+	case r == zeroWidthJoiner, r >= 0xfe00 && r <= 0xfe0f:
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // skin tone modifiers
+		return true
+	case r >= 0xe0020 && r <= 0xe007f: // tag sequences in flags
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// clusters splits s into the characters a terminal draws as one glyph: a
+// base code point with its combining marks, variation selectors and
+// modifiers, emoji joined with U+200D, and pairs of regional indicators
+// (flags). ANSI escape sequences are clusters of their own.
+func clusters(s string) []string {
+	var cs []string
+	start := -1
+	var prev rune
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			if start >= 0 {
+				cs = append(cs, s[start:i])
+				start = -1
+			}
+			cs = append(cs, s[i:i+n])
+			i += n
+			prev = 0
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		join := start >= 0 && (isExtending(r) || prev == zeroWidthJoiner ||
+			isRegionalIndicator(r) && isRegionalIndicator(prev) && runeCount(s[start:i]) == 1)
+		if !join {
+			if start >= 0 {
+				cs = append(cs, s[start:i])
+			}
+			start = i
+		}
+		prev = r
+		i += size
+	}
+	if start >= 0 {
+		cs = append(cs, s[start:])
+	}
+	return cs
+}
+
+// escapeLen returns the length of the ANSI CSI escape sequence, such as a
+// colour, at the start of s, or 0 if there is none.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+func firstRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+	return 0
+}
+
+func runeCount(s string) int {
+	return len([]rune(s))
+}
+
+// clusterWidth returns the number of cells one cluster occupies.
+func clusterWidth(c string) int {
+	r := firstRune(c)
+	switch {
+	case r < 0x20 || r == 0x7f || unicode.Is(unicode.Cf, r) || isExtending(r):
+		return 0
+	case isRegionalIndicator(r):
+		return 2
+	case strings.ContainsRune(c, emojiPresentation):
+		return 2
+	case strings.ContainsRune(c, textPresentation):
+		return 1
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// StringWidth returns the number of terminal cells s occupies on one line.
+func StringWidth(s string) int {
+	n := 0
+	for _, c := range clusters(s) {
+		n += clusterWidth(c)
+	}
+	return n
+}
+
+// Truncate shortens s to at most width cells, ending it with "…" when
+// anything was cut. Characters are never split.
+func Truncate(s string, width int) string {
+	return truncate(s, width, "…")
+}
+
+func truncate(s string, width int, ellipsis string) string {
+	if StringWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	ellipsis = takeWidth(ellipsis, width)
+	room := width - StringWidth(ellipsis)
+	var b strings.Builder
+	n := 0
+	for _, c := range clusters(s) {
+		cw := clusterWidth(c)
+		if n+cw > room {
+			break
+		}
+		b.WriteString(c)
+		n += cw
+	}
+	b.WriteString(ellipsis)
+	if strings.Contains(b.String(), "\x1b[") {
+		// Do not let a colour cut short run on.
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// PadRight pads s with spaces to width cells; longer strings are returned
+// unchanged.
+func PadRight(s string, width int) string {
+	if n := StringWidth(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// PadLeft is like PadRight but puts the spaces before s.
+func PadLeft(s string, width int) string {
+	if n := StringWidth(s); n < width {
+		return strings.Repeat(" ", width-n) + s
+	}
+	return s
+}
+
+// Wrap breaks s into lines of at most width cells, at spaces where possible.
+// Line breaks in s are kept; words longer than width are split.
+func Wrap(s string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var lines []string
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line, n := "", 0
+		for _, word := range strings.Fields(para) {
+			ww := StringWidth(word)
+			switch {
+			case n == 0:
+			case n+1+ww <= width:
+				line += " "
+				n++
+			default:
+				lines = append(lines, line)
+				line, n = "", 0
+			}
+			for ww > width {
+				// The word does not fit on a line of its own: split it,
+				// keeping at least one character per line.
+				head := takeWidth(word, width)
+				if head == "" {
+					head = clusters(word)[0]
+				}
+				lines = append(lines, head)
+				word = word[len(head):]
+				ww = StringWidth(word)
+			}
+			line += word
+			n += ww
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// takeWidth returns the longest prefix of s, in whole characters, that fits
+// in width cells.
+func takeWidth(s string, width int) string {
+	n, end := 0, 0
+	for _, c := range clusters(s) {
+		cw := clusterWidth(c)
+		if n+cw > width {
+			break
+		}
+		n += cw
+		end += len(c)
+	}
+	return s[:end]
+}
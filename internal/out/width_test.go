@@ -0,0 +1,161 @@
+package out
+
+import (
+	"strings"
+	"testing"
+)
+
+// --- StringWidth ---
+
+func TestStringWidth(t *testing.T) {
+	cases := map[string]int{
+		"":                           0,
+		"Sunny":                      5,
+		"Zürich":                     6,
+		"Zu\u0308rich":               6, // combining diaeresis
+		"☀️":                         2, // narrow symbol + VS16
+		"☀":                          1,
+		"⛅":                          2,
+		"🌧️":                         2,
+		"👍🏽":                         2, // skin tone modifier
+		"\U0001F469\u200d\U0001F33E": 2, // ZWJ sequence
+		"🇨🇭":                         2, // flag
+		"東京":                         4,
+		"Sunny (☀️)":                 10,
+	}
+	for s, want := range cases {
+		if got := StringWidth(s); got != want {
+			t.Errorf("StringWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+// --- Truncate ---
+
+func TestTruncate_shortString(t *testing.T) {
+	if got := Truncate("Sunny", 10); got != "Sunny" {
+		t.Errorf("Truncate(%q, 10) = %q, want %q", "Sunny", got, "Sunny")
+	}
+}
+
+func TestTruncate_exactWidth(t *testing.T) {
+	s := "1234567890"
+	if got := Truncate(s, 10); got != s {
+		t.Errorf("Truncate(%q, 10) = %q, want %q", s, got, s)
+	}
+}
+
+func TestTruncate_overWidth(t *testing.T) {
+	got := Truncate("Heavy thunderstorm expected tonight", 10)
+	if got != "Heavy thu…" {
+		t.Errorf("Truncate() = %q, want %q", got, "Heavy thu…")
+	}
+}
+
+func TestTruncate_emoji(t *testing.T) {
+	// The emoji is two cells wide and is never split from its selector.
+	got := Truncate("☀️ Sunny day ahead in Zurich", 8)
+	if got != "☀️ Sunn…" || StringWidth(got) != 8 {
+		t.Errorf("Truncate() = %q (width %d), want %q", got, StringWidth(got), "☀️ Sunn…")
+	}
+	if got := Truncate("ab⛅", 3); got != "ab…" {
+		t.Errorf("Truncate(%q, 3) = %q, want %q", "ab⛅", got, "ab…")
+	}
+}
+
+func TestTruncate_empty(t *testing.T) {
+	if got := Truncate("", 10); got != "" {
+		t.Errorf("Truncate(%q, 10) = %q, want %q", "", got, "")
+	}
+}
+
+// --- Pad ---
+
+func TestPad(t *testing.T) {
+	if got := PadRight("⛅x", 5); got != "⛅x  " {
+		t.Errorf("PadRight() = %q", got)
+	}
+	if got := PadLeft("4.5", 5); got != "  4.5" {
+		t.Errorf("PadLeft() = %q", got)
+	}
+}
+
+// --- Wrap ---
+
+func TestWrap(t *testing.T) {
+	got := Wrap("Heavy rain is expected tonight.\nAvoid rivers.", 12)
+	want := []string{"Heavy rain", "is expected", "tonight.", "Avoid", "rivers."}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Wrap() = %q, want %q", got, want)
+	}
+}
+
+func TestWrap_longWord(t *testing.T) {
+	got := Wrap("see https://www.meteoswiss.ch", 10)
+	for _, line := range got {
+		if StringWidth(line) > 10 {
+			t.Errorf("line %q is wider than 10", line)
+		}
+	}
+	if strings.Join(got, "") != "seehttps://www.meteoswiss.ch" {
+		t.Errorf("Wrap() = %q lost text", got)
+	}
+}
+
+// --- TextTable ---
+
+func TestTextTable_alignsEmoji(t *testing.T) {
+	tbl := TextTable{
+		Columns: []Column{{Header: "Conditions"}, {Header: "Max", Right: true}},
+		Rows:    [][]string{{"Sunny (☀️)", "14.0"}, {"Rain (🌧️)", "9.5"}},
+	}
+	header, rows, used := tbl.Lines(80)
+	want := []string{"Conditions   Max", "Sunny (☀️)  14.0", "Rain (🌧️)    9.5"}
+	if got := append([]string{header}, rows...); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Lines() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if used != 16 {
+		t.Errorf("used = %d, want 16", used)
+	}
+}
+
+func TestTextTable_shrinksThenDrops(t *testing.T) {
+	tbl := TextTable{
+		Columns: []Column{
+			{Header: "Date"},
+			{Header: "Conditions", Min: 6},
+			{Header: "Rain", Right: true, Drop: 1},
+		},
+		Rows: [][]string{{"2026-02-20", "Heavy snowfall", "12.5"}},
+	}
+	if _, rows, _ := tbl.Lines(28); rows[0] != "2026-02-20  Heavy sno…  12.5" {
+		t.Errorf("shrunk row = %q", rows[0])
+	}
+	if header, rows, used := tbl.Lines(20); header != "Date        Condi…" || rows[0] != "2026-02-20  Heavy…" || used != 18 {
+		t.Errorf("narrow = %q / %q (%d)", header, rows[0], used)
+	}
+}
+
+// --- UnicodeLocale ---
+
+func TestUnicodeLocale(t *testing.T) {
+	cases := []struct {
+		all, ctype, lang string
+		want             bool
+	}{
+		{"", "", "", true},
+		{"", "", "de_CH.UTF-8", true},
+		{"", "", "C", false},
+		{"", "en_US.utf8", "C", true},
+		{"C", "en_US.UTF-8", "en_US.UTF-8", false},
+		{"", "", "de_CH.ISO-8859-1", false},
+	}
+	for _, tc := range cases {
+		t.Setenv("LC_ALL", tc.all)
+		t.Setenv("LC_CTYPE", tc.ctype)
+		t.Setenv("LANG", tc.lang)
+		if got := UnicodeLocale(); got != tc.want {
+			t.Errorf("LC_ALL=%q LC_CTYPE=%q LANG=%q: UnicodeLocale() = %v, want %v", tc.all, tc.ctype, tc.lang, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package out
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// renderYAML writes v as a YAML document in block style. Only the subset of
+// YAML needed for JSON-shaped data is produced; strings that YAML would read
+// as another type are double-quoted.
+func renderYAML(w io.Writer, v any) error {
+	t, err := Tree(v)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if isEmptyOrScalar(t) {
+		b.WriteString(yamlScalar(t))
+		b.WriteByte('\n')
+	} else {
+		yamlBlock(&b, t, 0)
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// isEmptyOrScalar reports whether v is written inline rather than as a block.
+func isEmptyOrScalar(v any) bool {
+	switch v := v.(type) {
+	case Object:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return true
+}
+
+// yamlBlock writes a non-empty object or list, each line indented by indent
+// spaces.
+func yamlBlock(b *strings.Builder, v any, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch v := v.(type) {
+	case Object:
+		for _, m := range v {
+			b.WriteString(pad + yamlKey(m.Key) + ":")
+			if isEmptyOrScalar(m.Value) {
+				b.WriteString(" " + yamlScalar(m.Value) + "\n")
+				continue
+			}
+			b.WriteByte('\n')
+			yamlBlock(b, m.Value, indent+2)
+		}
+	case []any:
+		for _, item := range v {
+			if isEmptyOrScalar(item) {
+				b.WriteString(pad + "- " + yamlScalar(item) + "\n")
+				continue
+			}
+			// Write the nested block one level deeper, then turn the
+			// indentation of its first line into the list marker.
+			var nested strings.Builder
+			yamlBlock(&nested, item, indent+2)
+			b.WriteString(pad + "- " + nested.String()[indent+2:])
+		}
+	}
+}
+
+func yamlKey(k string) string {
+	if k != "" && !needsQuotes(k) {
+		return k
+	}
+	return quoteYAML(k)
+}
+
+func yamlScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if needsQuotes(v) {
+			return quoteYAML(v)
+		}
+		return v
+	case Object:
+		return "{}"
+	case []any:
+		return "[]"
+	}
+	return quoteYAML(scalarString(v))
+}
+
+// needsQuotes reports whether a plain YAML scalar would not read back as the
+// string s.
+func needsQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`0123456789.+") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteYAML double-quotes s. JSON string escapes are valid in YAML
+// double-quoted scalars.
+func quoteYAML(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,164 @@
+// Package schema derives JSON Schemas from the Go types that meteocli encodes
+// as JSON, so that the published schemas cannot drift from the output.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema.
+type Schema struct {
+	Schema      string     `json:"$schema,omitempty"`
+	ID          string     `json:"$id,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Type        Types      `json:"type,omitempty"`
+	Format      string     `json:"format,omitempty"`
+	Properties  Properties `json:"properties,omitempty"`
+	Required    []string   `json:"required,omitempty"`
+	Items       *Schema    `json:"items,omitempty"`
+	OneOf       []*Schema  `json:"oneOf,omitempty"`
+
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+}
+
+// Types is the "type" keyword: a single type name, or a list when a value
+// may be null.
+type Types []string
+
+// MarshalJSON encodes a single type as a string.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Property is a named member of Properties.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties lists an object's properties in field order.
+type Properties []Property
+
+// MarshalJSON encodes the properties as an object, keeping their order.
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(prop.Name)
+		s, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(s)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// For returns the schema of the JSON encoding of v's type, following the
+// rules of encoding/json: field names come from json tags, "-" and
+// unexported fields are skipped, embedded structs are inlined and omitempty
+// fields are optional. Slices that are not omitempty may encode as null.
+func For(v any) *Schema {
+	return reflectType(reflect.TypeOf(v))
+}
+
+func reflectType(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t == timeType {
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := reflectType(t.Elem())
+		return nullable(s)
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: Types{"array"}, Items: reflectType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: reflectType(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{Type: Types{"object"}}
+		addFields(s, t)
+		return s
+	}
+	return &Schema{}
+}
+
+func addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		omitempty := strings.Contains(","+opts+",", ",omitempty,")
+		fs := reflectType(f.Type)
+		if f.Type.Kind() == reflect.Slice && !omitempty {
+			fs = nullable(fs)
+		}
+		s.Properties = append(s.Properties, Property{Name: name, Schema: fs})
+		if !omitempty {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// nullable allows null in addition to the types of s.
+func nullable(s *Schema) *Schema {
+	if len(s.Type) == 0 {
+		return s
+	}
+	for _, t := range s.Type {
+		if t == "null" {
+			return s
+		}
+	}
+	s.Type = append(s.Type, "null")
+	return s
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type inner struct {
+	Start time.Time `json:"start"`
+}
+
+type embedded struct {
+	Icon int `json:"icon"`
+}
+
+type sample struct {
+	embedded
+	Name    string            `json:"name"`
+	Temp    float64           `json:"temp"`
+	At      *time.Time        `json:"at,omitempty"`
+	Regions []string          `json:"regions"`
+	Slots   []inner           `json:"slots,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Skipped string            `json:"-"`
+	hidden  int
+}
+
+func TestFor(t *testing.T) {
+	data, err := json.Marshal(For(sample{}))
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	want := `{"type":"object","properties":{` +
+		`"icon":{"type":"integer"},` +
+		`"name":{"type":"string"},` +
+		`"temp":{"type":"number"},` +
+		`"at":{"type":["string","null"],"format":"date-time"},` +
+		`"regions":{"type":["array","null"],"items":{"type":"string"}},` +
+		`"slots":{"type":"array","items":{"type":"object","properties":{"start":{"type":"string","format":"date-time"}},"required":["start"]}},` +
+		`"labels":{"type":"object","additionalProperties":{"type":"string"}}` +
+		`},"required":["icon","name","temp","regions"]}`
+	if string(data) != want {
+		t.Errorf("For(sample) =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestFor_topLevelSlice(t *testing.T) {
+	data, _ := json.Marshal(For([]inner{}))
+	if !strings.HasPrefix(string(data), `{"type":"array","items":{"type":"object"`) {
+		t.Errorf("For([]inner) = %s, want a non-null array of objects", data)
+	}
+}
@@ -0,0 +1,218 @@
+// Package units converts the metric values of the MeteoSwiss API into the
+// units selected with --units and formats them for display.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Unit systems accepted by Parse.
+const (
+	SystemMetric   = "metric"
+	SystemImperial = "imperial"
+	SystemCustom   = "custom"
+)
+
+// Temperature units.
+const (
+	Celsius    = "C"
+	Fahrenheit = "F"
+	Kelvin     = "K"
+)
+
+// Precipitation units.
+const (
+	Millimetres = "mm"
+	Inches      = "in"
+)
+
+// Wind speed units.
+const (
+	KMH      = "km/h"
+	MS       = "m/s"
+	Knots    = "kn"
+	MPH      = "mph"
+	Beaufort = "bft"
+)
+
+var (
+	temperatureUnits   = []string{Celsius, Fahrenheit, Kelvin}
+	precipitationUnits = []string{Millimetres, Inches}
+	windUnits          = []string{KMH, MS, Knots, MPH, Beaufort}
+)
+
+// System is the unit for each quantity. The zero System is Metric.
+type System struct {
+	Temperature   string `json:"temperature"`
+	Precipitation string `json:"precipitation"`
+	Wind          string `json:"wind"`
+}
+
+// The predefined systems.
+var (
+	Metric   = System{Temperature: Celsius, Precipitation: Millimetres, Wind: KMH}
+	Imperial = System{Temperature: Fahrenheit, Precipitation: Inches, Wind: MPH}
+)
+
+// Parse returns the system called name with the non-empty overrides for
+// temperature, precipitation and wind applied. "custom" starts from metric
+// and is meant to be combined with overrides.
+func Parse(name, temperature, precipitation, wind string) (System, error) {
+	var s System
+	switch name {
+	case SystemMetric, SystemCustom, "":
+		s = Metric
+	case SystemImperial:
+		s = Imperial
+	default:
+		return System{}, fmt.Errorf("invalid --units %q: want %s, %s or %s", name, SystemMetric, SystemImperial, SystemCustom)
+	}
+	for _, o := range []struct {
+		flag  string
+		value string
+		dst   *string
+		valid []string
+	}{
+		{"--temp-unit", temperature, &s.Temperature, temperatureUnits},
+		{"--precip-unit", precipitation, &s.Precipitation, precipitationUnits},
+		{"--wind-unit", wind, &s.Wind, windUnits},
+	} {
+		if o.value == "" {
+			continue
+		}
+		v, ok := lookup(o.value, o.valid)
+		if !ok {
+			return System{}, fmt.Errorf("invalid %s %q: want one of %s", o.flag, o.value, strings.Join(o.valid, ", "))
+		}
+		*o.dst = v
+	}
+	return s, nil
+}
+
+// lookup finds v among valid, ignoring case and a leading degree sign.
+func lookup(v string, valid []string) (string, bool) {
+	v = strings.TrimPrefix(v, "°")
+	for _, u := range valid {
+		if strings.EqualFold(v, u) {
+			return u, true
+		}
+	}
+	return "", false
+}
+
+func (s System) orMetric() System {
+	if s == (System{}) {
+		return Metric
+	}
+	return s
+}
+
+// Name returns "metric" or "imperial" for the predefined systems and
+// "custom" otherwise.
+func (s System) Name() string {
+	switch s.orMetric() {
+	case Metric:
+		return SystemMetric
+	case Imperial:
+		return SystemImperial
+	}
+	return SystemCustom
+}
+
+// Temp converts a temperature in °C.
+func (s System) Temp(c float64) float64 {
+	switch s.orMetric().Temperature {
+	case Fahrenheit:
+		return c*9/5 + 32
+	case Kelvin:
+		return c + 273.15
+	}
+	return c
+}
+
+// TempUnit returns the symbol of the temperature unit, e.g. "°C" or "K".
+func (s System) TempUnit() string {
+	if u := s.orMetric().Temperature; u != Kelvin {
+		return "°" + u
+	}
+	return Kelvin
+}
+
+// FormatTemp formats a temperature in °C with one decimal, without the
+// unit.
+func (s System) FormatTemp(c float64) string {
+	return fmt.Sprintf("%.1f", s.Temp(c))
+}
+
+// Precip converts a precipitation amount in mm, or a rate in mm/h to the
+// unit per hour.
+func (s System) Precip(mm float64) float64 {
+	if s.orMetric().Precipitation == Inches {
+		return mm / 25.4
+	}
+	return mm
+}
+
+// PrecipUnit returns the symbol of the precipitation unit.
+func (s System) PrecipUnit() string {
+	return s.orMetric().Precipitation
+}
+
+// PrecipDecimals returns the number of decimals precipitation is shown
+// with: one for millimetres and two for inches.
+func (s System) PrecipDecimals() int {
+	if s.orMetric().Precipitation == Inches {
+		return 2
+	}
+	return 1
+}
+
+// FormatPrecip formats a precipitation amount in mm, without the unit.
+func (s System) FormatPrecip(mm float64) string {
+	return fmt.Sprintf("%.*f", s.PrecipDecimals(), s.Precip(mm))
+}
+
+// beaufortLimits are the lower bounds in km/h of Beaufort forces 1 to 12.
+var beaufortLimits = []float64{1, 6, 12, 20, 29, 39, 50, 62, 75, 89, 103, 118}
+
+// WindSpeed converts a wind speed in km/h. Beaufort forces are whole numbers
+// from 0 to 12.
+func (s System) WindSpeed(kmh float64) float64 {
+	switch s.orMetric().Wind {
+	case MS:
+		return kmh / 3.6
+	case Knots:
+		return kmh / 1.852
+	case MPH:
+		return kmh / 1.609344
+	case Beaufort:
+		force := 0
+		for _, limit := range beaufortLimits {
+			if math.Round(kmh) >= limit {
+				force++
+			}
+		}
+		return float64(force)
+	}
+	return kmh
+}
+
+// WindUnit returns the symbol of the wind speed unit.
+func (s System) WindUnit() string {
+	return s.orMetric().Wind
+}
+
+// FormatWind formats a wind speed in km/h with its unit, e.g. "12 km/h",
+// "3.3 m/s" or "Bft 2".
+func (s System) FormatWind(kmh float64) string {
+	switch u := s.orMetric().Wind; u {
+	case Beaufort:
+		return fmt.Sprintf("Bft %.0f", s.WindSpeed(kmh))
+	case MS:
+		return fmt.Sprintf("%.1f %s", s.WindSpeed(kmh), u)
+	default:
+		return fmt.Sprintf("%.0f %s", s.WindSpeed(kmh), u)
+	}
+}
@@ -0,0 +1,113 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// --- Parse ---
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name, temp, precip, wind string
+		want                     System
+		wantName                 string
+	}{
+		{"metric", "", "", "", Metric, SystemMetric},
+		{"", "", "", "", Metric, SystemMetric},
+		{"imperial", "", "", "", Imperial, SystemImperial},
+		{"imperial", "", "mm", "", System{Fahrenheit, Millimetres, MPH}, SystemCustom},
+		{"custom", "°f", "", "KN", System{Fahrenheit, Millimetres, Knots}, SystemCustom},
+		{"metric", "K", "in", "bft", System{Kelvin, Inches, Beaufort}, SystemCustom},
+	}
+	for _, tc := range cases {
+		got, err := Parse(tc.name, tc.temp, tc.precip, tc.wind)
+		if err != nil {
+			t.Errorf("Parse(%q, %q, %q, %q) error: %v", tc.name, tc.temp, tc.precip, tc.wind, err)
+			continue
+		}
+		if got != tc.want || got.Name() != tc.wantName {
+			t.Errorf("Parse(%q, %q, %q, %q) = %+v (%s), want %+v (%s)",
+				tc.name, tc.temp, tc.precip, tc.wind, got, got.Name(), tc.want, tc.wantName)
+		}
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	for _, args := range [][4]string{
+		{"nautical", "", "", ""},
+		{"metric", "R", "", ""},
+		{"metric", "", "cm", ""},
+		{"metric", "", "", "furlongs/fortnight"},
+	} {
+		if _, err := Parse(args[0], args[1], args[2], args[3]); err == nil {
+			t.Errorf("Parse(%q): expected error, got nil", args)
+		}
+	}
+}
+
+// --- conversions ---
+
+func TestTemp(t *testing.T) {
+	if got := (System{Temperature: Fahrenheit}).Temp(-40); !approx(got, -40) {
+		t.Errorf("-40 °C = %v °F, want -40", got)
+	}
+	if got := Imperial.Temp(20); !approx(got, 68) {
+		t.Errorf("20 °C = %v °F, want 68", got)
+	}
+	if got := (System{Temperature: Kelvin}).Temp(0); !approx(got, 273.15) {
+		t.Errorf("0 °C = %v K, want 273.15", got)
+	}
+	if got := (System{}).Temp(12.5); got != 12.5 {
+		t.Errorf("zero System converted 12.5 °C to %v", got)
+	}
+	if Imperial.TempUnit() != "°F" || (System{Temperature: Kelvin}).TempUnit() != "K" {
+		t.Errorf("TempUnit() = %q, %q", Imperial.TempUnit(), (System{Temperature: Kelvin}).TempUnit())
+	}
+}
+
+func TestPrecip(t *testing.T) {
+	if got := Imperial.FormatPrecip(25.4); got != "1.00" {
+		t.Errorf("FormatPrecip(25.4 mm) = %q, want 1.00 (in)", got)
+	}
+	if got := Metric.FormatPrecip(2.34); got != "2.3" {
+		t.Errorf("FormatPrecip(2.34 mm) = %q, want 2.3", got)
+	}
+}
+
+func TestWindSpeed(t *testing.T) {
+	cases := []struct {
+		unit string
+		kmh  float64
+		want float64
+	}{
+		{KMH, 36, 36},
+		{MS, 36, 10},
+		{Knots, 18.52, 10},
+		{MPH, 16.09344, 10},
+		{Beaufort, 0.4, 0},
+		{Beaufort, 5.4, 1},
+		{Beaufort, 5.6, 2},
+		{Beaufort, 61, 7},
+		{Beaufort, 62, 8},
+		{Beaufort, 150, 12},
+	}
+	for _, tc := range cases {
+		if got := (System{Wind: tc.unit}).WindSpeed(tc.kmh); !approx(got, tc.want) {
+			t.Errorf("%v km/h in %s = %v, want %v", tc.kmh, tc.unit, got, tc.want)
+		}
+	}
+}
+
+func TestFormatWind(t *testing.T) {
+	cases := map[string]string{KMH: "12 km/h", MS: "3.3 m/s", Beaufort: "Bft 3"}
+	for unit, want := range cases {
+		if got := (System{Wind: unit}).FormatWind(12); got != want {
+			t.Errorf("FormatWind(12 km/h) in %s = %q, want %q", unit, got, want)
+		}
+	}
+}